@@ -0,0 +1,251 @@
+package onethingai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// ==================== Audio Transcription ====================
+
+// AudioResponseFormat 语音转写结果格式枚举
+type AudioResponseFormat string
+
+const (
+	AudioResponseFormatJSON        AudioResponseFormat = "json"
+	AudioResponseFormatText        AudioResponseFormat = "text"
+	AudioResponseFormatSRT         AudioResponseFormat = "srt"
+	AudioResponseFormatVTT         AudioResponseFormat = "vtt"
+	AudioResponseFormatVerboseJSON AudioResponseFormat = "verbose_json"
+)
+
+// TimestampGranularity 转写时间戳粒度枚举
+type TimestampGranularity string
+
+const (
+	TimestampGranularityWord    TimestampGranularity = "word"
+	TimestampGranularitySegment TimestampGranularity = "segment"
+)
+
+// AudioTranscriptionRequest 语音转写请求。File 优先于 URL：设置 File 时请求以
+// multipart/form-data 上传，否则以 JSON 请求体携带 URL。
+type AudioTranscriptionRequest struct {
+	Model                  string                 `json:"model"`
+	URL                    *string                `json:"url,omitempty"`
+	File                   io.Reader              `json:"-"`
+	FileName               string                 `json:"-"`
+	Language               string                 `json:"language,omitempty"`
+	ResponseFormat         AudioResponseFormat    `json:"response_format,omitempty"`
+	Temperature            *float64               `json:"temperature,omitempty"`
+	TimestampGranularities []TimestampGranularity `json:"timestamp_granularities,omitempty"`
+	Stream                 *bool                  `json:"stream,omitempty"`
+}
+
+// TranscriptSegment 转写分段
+type TranscriptSegment struct {
+	ID    int     `json:"id"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Text  string  `json:"text"`
+}
+
+// TranscriptWord 词级时间戳
+type TranscriptWord struct {
+	Word  string  `json:"word"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// AudioTranscriptionResponse 语音转写结果
+type AudioTranscriptionResponse struct {
+	Text     string              `json:"text"`
+	Language string              `json:"language,omitempty"`
+	Duration float64             `json:"duration,omitempty"`
+	Segments []TranscriptSegment `json:"segments,omitempty"`
+	Words    []TranscriptWord    `json:"words,omitempty"`
+}
+
+// TranscriptChunk 流式转写增量
+type TranscriptChunk struct {
+	Text  string `json:"text"`
+	Delta string `json:"delta,omitempty"`
+	Done  bool   `json:"done,omitempty"`
+}
+
+// AudioDataResponse 异步语音转写任务响应类型，复用与图片/视频相同的轮询结构
+type AudioDataResponse = ImageAndVideoDataResponse[AudioTranscriptionResponse]
+type AudioResponse = Response[AudioDataResponse]
+
+// NewAudioResponse builds an AudioResponse from a decoded map or raw bytes
+func NewAudioResponse(val interface{}) (*AudioResponse, error) {
+	return NewResponse[AudioDataResponse](val)
+}
+
+// TranscribeAudio transcribes audio from a URL or an uploaded file
+func (c *Client) TranscribeAudio(ctx context.Context, req *AudioTranscriptionRequest) (*AudioTranscriptionResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if err := ValidateModel(req.Model); err != nil {
+		return nil, err
+	}
+	if req.URL == nil && req.File == nil {
+		return nil, NewValidationError("file", "either URL or File must be provided")
+	}
+
+	if req.File != nil {
+		return c.transcribeAudioMultipart(ctx, req)
+	}
+
+	var resp AudioTranscriptionResponse
+	if err := c.doRequest(ctx, "POST", "/audio/transcriptions", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// transcribeAudioMultipart streams req.File through
+// Transport.DoMultipartRequest, the same multipart uploader
+// GenerateImageWithFiles uses for attachments, so the request is logged,
+// hooked, and breaker-protected like DoRequest calls.
+func (c *Client) transcribeAudioMultipart(ctx context.Context, req *AudioTranscriptionRequest) (*AudioTranscriptionResponse, error) {
+	fileName := req.FileName
+	if fileName == "" {
+		fileName = "audio"
+	}
+
+	fields := url.Values{"model": {req.Model}}
+	if req.Language != "" {
+		fields.Set("language", req.Language)
+	}
+	if req.ResponseFormat != "" {
+		fields.Set("response_format", string(req.ResponseFormat))
+	}
+	if req.Temperature != nil {
+		fields.Set("temperature", fmt.Sprintf("%g", *req.Temperature))
+	}
+	for _, g := range req.TimestampGranularities {
+		fields.Add("timestamp_granularities[]", string(g))
+	}
+
+	files := []FileField{{Name: "file", Filename: fileName, Reader: req.File}}
+
+	resp, err := c.transport.DoMultipartRequest(ctx, "POST", "/audio/transcriptions", fields, files)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var out AudioTranscriptionResponse
+	if err := json.Unmarshal(respBody, &out); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &out, nil
+}
+
+// TranscribeAudioStreaming transcribes a URL-referenced audio file, emitting
+// partial transcripts as they become available. File uploads are not
+// supported on the streaming path.
+func (c *Client) TranscribeAudioStreaming(ctx context.Context, req *AudioTranscriptionRequest) (*StreamReader[TranscriptChunk], error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if err := ValidateModel(req.Model); err != nil {
+		return nil, err
+	}
+	if req.URL == nil {
+		return nil, NewValidationError("url", "streaming transcription requires a URL input")
+	}
+
+	stream := true
+	req.Stream = &stream
+
+	resp, err := c.doStreamRequest(ctx, "POST", "/audio/transcriptions", req)
+	if err != nil {
+		return nil, err
+	}
+	return NewStreamReader[TranscriptChunk](ctx, resp), nil
+}
+
+// SubmitTranscriptionJob submits a long-running async transcription job
+func (c *Client) SubmitTranscriptionJob(ctx context.Context, req *AudioTranscriptionRequest) (*AudioResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if err := ValidateModel(req.Model); err != nil {
+		return nil, err
+	}
+	if req.URL == nil {
+		return nil, NewValidationError("url", "async transcription jobs require a URL input")
+	}
+
+	var resp AudioResponse
+	if err := c.doRequest(ctx, "POST", "/audio/transcriptions/jobs", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetTranscriptionJobStatus retrieves the status of an async transcription job
+func (c *Client) GetTranscriptionJobStatus(ctx context.Context, jobID string) (*AudioResponse, error) {
+	var respMap map[string]interface{}
+	path := fmt.Sprintf("/audio/transcriptions/jobs/%s", jobID)
+	if err := c.doRequest(ctx, "GET", path, nil, &respMap); err != nil {
+		return nil, err
+	}
+	return NewAudioResponse(respMap)
+}
+
+// WaitForTranscription polls an async transcription job until it completes,
+// reusing the same PollingOptions/OnProgress pattern as video jobs.
+func (c *Client) WaitForTranscription(ctx context.Context, jobID string, opts *PollingOptions) (*AudioResponse, error) {
+	return pollJobStatus(ctx, jobID, opts, c.GetTranscriptionJobStatus)
+}
+
+// ==================== Text-to-Speech ====================
+
+// AudioSpeechFormat 语音合成输出格式枚举
+type AudioSpeechFormat string
+
+const (
+	AudioSpeechFormatMP3  AudioSpeechFormat = "mp3"
+	AudioSpeechFormatWAV  AudioSpeechFormat = "wav"
+	AudioSpeechFormatOpus AudioSpeechFormat = "opus"
+	AudioSpeechFormatFLAC AudioSpeechFormat = "flac"
+)
+
+// AudioSpeechRequest 语音合成请求
+type AudioSpeechRequest struct {
+	Model  string            `json:"model"`
+	Input  string            `json:"input"`
+	Voice  string            `json:"voice"`
+	Format AudioSpeechFormat `json:"format,omitempty"`
+	Speed  *float64          `json:"speed,omitempty"`
+}
+
+// GenerateSpeech synthesizes speech and returns a reader over the raw audio
+// bytes in the requested Format; callers are responsible for closing it.
+func (c *Client) GenerateSpeech(ctx context.Context, req *AudioSpeechRequest) (io.ReadCloser, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if err := ValidateModel(req.Model); err != nil {
+		return nil, err
+	}
+	if req.Input == "" {
+		return nil, NewValidationError("input", "input is required")
+	}
+
+	resp, err := c.transport.DoRawRequest(ctx, "POST", "/audio/speech", req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
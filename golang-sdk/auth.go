@@ -0,0 +1,89 @@
+package onethingai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// dynamicTokenRefreshSkew is how far ahead of a DynamicTokenAuth token's
+// expiry Apply proactively refreshes it, to avoid racing a request against
+// an expiring token.
+const dynamicTokenRefreshSkew = 30 * time.Second
+
+// Authenticator applies credentials to an outgoing request. Transport calls
+// Apply on every attempt, right before it's sent, in place of the
+// hardcoded "Authorization: Bearer <apiKey>" header NewClient used to set
+// directly; see WithAuthenticator.
+type Authenticator interface {
+	Apply(req *http.Request) error
+}
+
+// BearerTokenAuth sets a static "Authorization: Bearer <Token>" header.
+// This is what NewClient(apiKey, ...) uses by default.
+type BearerTokenAuth struct {
+	Token string
+}
+
+// Apply implements Authenticator.
+func (a BearerTokenAuth) Apply(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+	return nil
+}
+
+// StaticHeaderAuth sets a single fixed header, e.g. for gateways that
+// expect a custom API-key header or a pre-computed HMAC signature instead
+// of a bearer token.
+type StaticHeaderAuth struct {
+	Header string
+	Value  string
+}
+
+// Apply implements Authenticator.
+func (a StaticHeaderAuth) Apply(req *http.Request) error {
+	req.Header.Set(a.Header, a.Value)
+	return nil
+}
+
+// TokenFetchFunc fetches a fresh bearer token along with the time it
+// expires at, for use with DynamicTokenAuth.
+type TokenFetchFunc func(ctx context.Context) (token string, exp time.Time, err error)
+
+// DynamicTokenAuth caches a bearer token obtained from fetch and
+// transparently refreshes it shortly before it expires, for gateways that
+// issue short-lived tokens rather than accepting a long-lived API key
+// directly.
+type DynamicTokenAuth struct {
+	fetch TokenFetchFunc
+
+	mu    sync.Mutex
+	token string
+	exp   time.Time
+}
+
+// NewDynamicTokenAuth returns a DynamicTokenAuth that calls fetch to obtain
+// (and later refresh) its token.
+func NewDynamicTokenAuth(fetch TokenFetchFunc) *DynamicTokenAuth {
+	return &DynamicTokenAuth{fetch: fetch}
+}
+
+// Apply implements Authenticator. It reuses the cached token until it's
+// within dynamicTokenRefreshSkew of exp, then calls fetch again.
+func (a *DynamicTokenAuth) Apply(req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token == "" || (!a.exp.IsZero() && time.Now().After(a.exp.Add(-dynamicTokenRefreshSkew))) {
+		token, exp, err := a.fetch(req.Context())
+		if err != nil {
+			return fmt.Errorf("failed to refresh auth token: %w", err)
+		}
+		a.token = token
+		a.exp = exp
+	}
+
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	return nil
+}
@@ -0,0 +1,493 @@
+package onethingai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ==================== Backend Abstraction ====================
+
+// Backend is implemented by anything that can serve the SDK's core
+// operations, so a single Client can fan out across multiple upstreams
+// (OneThing AI, OpenAI, a local LocalAI instance, a secondary region, ...)
+// from one call site.
+type Backend interface {
+	ChatCompletion(ctx context.Context, req map[string]interface{}) (*TextResponse, error)
+	Completions(ctx context.Context, req map[string]interface{}) (*TextResponse, error)
+	Responses(ctx context.Context, req map[string]interface{}) (*TextResponse, error)
+	ChatCompletionStreaming(ctx context.Context, req map[string]interface{}) (*TextStreamReader, error)
+	CompletionsStreaming(ctx context.Context, req map[string]interface{}) (*TextStreamReader, error)
+	ResponsesStreaming(ctx context.Context, req map[string]interface{}) (*TextStreamReader, error)
+	Embeddings(ctx context.Context, req *EmbeddingsRequest) (*EmbeddingsResponse, error)
+	GenerateVideo(ctx context.Context, req interface{}) (*VideoResponse, error)
+}
+
+// HTTPBackend adapts a Client's own HTTP implementation to the Backend
+// interface, so it can sit in a Router/FailoverBackend/WeightedBackend
+// alongside backends for other providers.
+type HTTPBackend struct {
+	Client *Client
+}
+
+func (b *HTTPBackend) ChatCompletion(ctx context.Context, req map[string]interface{}) (*TextResponse, error) {
+	return b.Client.ChatCompletion(ctx, req)
+}
+
+func (b *HTTPBackend) Completions(ctx context.Context, req map[string]interface{}) (*TextResponse, error) {
+	return b.Client.Completions(ctx, req)
+}
+
+func (b *HTTPBackend) Responses(ctx context.Context, req map[string]interface{}) (*TextResponse, error) {
+	return b.Client.Responses(ctx, req)
+}
+
+func (b *HTTPBackend) ChatCompletionStreaming(ctx context.Context, req map[string]interface{}) (*TextStreamReader, error) {
+	return b.Client.ChatCompletionStreaming(ctx, req)
+}
+
+func (b *HTTPBackend) CompletionsStreaming(ctx context.Context, req map[string]interface{}) (*TextStreamReader, error) {
+	return b.Client.CompletionsStreaming(ctx, req)
+}
+
+func (b *HTTPBackend) ResponsesStreaming(ctx context.Context, req map[string]interface{}) (*TextStreamReader, error) {
+	return b.Client.ResponsesStreaming(ctx, req)
+}
+
+func (b *HTTPBackend) Embeddings(ctx context.Context, req *EmbeddingsRequest) (*EmbeddingsResponse, error) {
+	return b.Client.Embeddings(ctx, req)
+}
+
+func (b *HTTPBackend) GenerateVideo(ctx context.Context, req interface{}) (*VideoResponse, error) {
+	return b.Client.GenerateVideo(ctx, req)
+}
+
+// ==================== Router ====================
+
+type prefixRoute struct {
+	prefix  string
+	backend Backend
+}
+
+type predicateRoute struct {
+	match   func(model, jobType string) bool
+	backend Backend
+}
+
+// Router selects a Backend for a request by exact model match, then model
+// prefix, then a user-supplied predicate, in that priority order.
+type Router struct {
+	mu         sync.RWMutex
+	byModel    map[string]Backend
+	byPrefix   []prefixRoute
+	predicates []predicateRoute
+}
+
+// NewRouter creates an empty Router
+func NewRouter() *Router {
+	return &Router{byModel: make(map[string]Backend)}
+}
+
+// Register routes requests for an exact model name to backend
+func (r *Router) Register(model string, backend Backend) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byModel[model] = backend
+}
+
+// RegisterPrefix routes requests whose model name starts with prefix to backend
+func (r *Router) RegisterPrefix(prefix string, backend Backend) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byPrefix = append(r.byPrefix, prefixRoute{prefix: prefix, backend: backend})
+}
+
+// RegisterFunc routes requests matched by a custom predicate to backend
+func (r *Router) RegisterFunc(match func(model, jobType string) bool, backend Backend) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.predicates = append(r.predicates, predicateRoute{match: match, backend: backend})
+}
+
+// Resolve returns the Backend registered for model/jobType
+func (r *Router) Resolve(model, jobType string) (Backend, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if backend, ok := r.byModel[model]; ok {
+		return backend, nil
+	}
+	for _, route := range r.byPrefix {
+		if strings.HasPrefix(model, route.prefix) {
+			return route.backend, nil
+		}
+	}
+	for _, route := range r.predicates {
+		if route.match(model, jobType) {
+			return route.backend, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no backend registered for model %q", model)
+}
+
+// ==================== Failover Policy ====================
+
+// isFailoverable reports whether err should trigger a fallback to the next
+// backend: 5xx responses, rate limiting, or the request timing out.
+func isFailoverable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if IsServerError(err) || IsRateLimitError(err) {
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrTimeout)
+}
+
+// FailoverBackend tries each backend in Backends in order, falling through
+// to the next on a failoverable error, and returns the last error once all
+// backends have been exhausted.
+type FailoverBackend struct {
+	Backends []Backend
+}
+
+func (f *FailoverBackend) ChatCompletion(ctx context.Context, req map[string]interface{}) (*TextResponse, error) {
+	var lastErr error
+	for _, b := range f.Backends {
+		resp, err := b.ChatCompletion(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		if !isFailoverable(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("all backends failed: %w", lastErr)
+}
+
+func (f *FailoverBackend) Completions(ctx context.Context, req map[string]interface{}) (*TextResponse, error) {
+	var lastErr error
+	for _, b := range f.Backends {
+		resp, err := b.Completions(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		if !isFailoverable(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("all backends failed: %w", lastErr)
+}
+
+func (f *FailoverBackend) Responses(ctx context.Context, req map[string]interface{}) (*TextResponse, error) {
+	var lastErr error
+	for _, b := range f.Backends {
+		resp, err := b.Responses(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		if !isFailoverable(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("all backends failed: %w", lastErr)
+}
+
+func (f *FailoverBackend) ChatCompletionStreaming(ctx context.Context, req map[string]interface{}) (*TextStreamReader, error) {
+	var lastErr error
+	for _, b := range f.Backends {
+		reader, err := b.ChatCompletionStreaming(ctx, req)
+		if err == nil {
+			return reader, nil
+		}
+		lastErr = err
+		if !isFailoverable(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("all backends failed: %w", lastErr)
+}
+
+func (f *FailoverBackend) CompletionsStreaming(ctx context.Context, req map[string]interface{}) (*TextStreamReader, error) {
+	var lastErr error
+	for _, b := range f.Backends {
+		reader, err := b.CompletionsStreaming(ctx, req)
+		if err == nil {
+			return reader, nil
+		}
+		lastErr = err
+		if !isFailoverable(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("all backends failed: %w", lastErr)
+}
+
+func (f *FailoverBackend) ResponsesStreaming(ctx context.Context, req map[string]interface{}) (*TextStreamReader, error) {
+	var lastErr error
+	for _, b := range f.Backends {
+		reader, err := b.ResponsesStreaming(ctx, req)
+		if err == nil {
+			return reader, nil
+		}
+		lastErr = err
+		if !isFailoverable(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("all backends failed: %w", lastErr)
+}
+
+func (f *FailoverBackend) Embeddings(ctx context.Context, req *EmbeddingsRequest) (*EmbeddingsResponse, error) {
+	var lastErr error
+	for _, b := range f.Backends {
+		resp, err := b.Embeddings(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		if !isFailoverable(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("all backends failed: %w", lastErr)
+}
+
+func (f *FailoverBackend) GenerateVideo(ctx context.Context, req interface{}) (*VideoResponse, error) {
+	var lastErr error
+	for _, b := range f.Backends {
+		resp, err := b.GenerateVideo(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		if !isFailoverable(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("all backends failed: %w", lastErr)
+}
+
+// ==================== Weighted Load Balancing ====================
+
+// WeightedChoice pairs a Backend with its relative selection weight
+type WeightedChoice struct {
+	Backend Backend
+	Weight  int
+}
+
+// WeightedBackend load-balances across its choices proportionally to Weight
+type WeightedBackend struct {
+	mu      sync.Mutex
+	choices []WeightedChoice
+	total   int
+	rng     *rand.Rand
+}
+
+// NewWeightedBackend builds a WeightedBackend over choices
+func NewWeightedBackend(choices []WeightedChoice) *WeightedBackend {
+	total := 0
+	for _, c := range choices {
+		total += c.Weight
+	}
+	return &WeightedBackend{
+		choices: choices,
+		total:   total,
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (w *WeightedBackend) pick() (Backend, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.total <= 0 || len(w.choices) == 0 {
+		return nil, fmt.Errorf("no weighted backends configured")
+	}
+
+	n := w.rng.Intn(w.total)
+	for _, c := range w.choices {
+		if n < c.Weight {
+			return c.Backend, nil
+		}
+		n -= c.Weight
+	}
+	return w.choices[len(w.choices)-1].Backend, nil
+}
+
+func (w *WeightedBackend) ChatCompletion(ctx context.Context, req map[string]interface{}) (*TextResponse, error) {
+	b, err := w.pick()
+	if err != nil {
+		return nil, err
+	}
+	return b.ChatCompletion(ctx, req)
+}
+
+func (w *WeightedBackend) Completions(ctx context.Context, req map[string]interface{}) (*TextResponse, error) {
+	b, err := w.pick()
+	if err != nil {
+		return nil, err
+	}
+	return b.Completions(ctx, req)
+}
+
+func (w *WeightedBackend) Responses(ctx context.Context, req map[string]interface{}) (*TextResponse, error) {
+	b, err := w.pick()
+	if err != nil {
+		return nil, err
+	}
+	return b.Responses(ctx, req)
+}
+
+func (w *WeightedBackend) ChatCompletionStreaming(ctx context.Context, req map[string]interface{}) (*TextStreamReader, error) {
+	b, err := w.pick()
+	if err != nil {
+		return nil, err
+	}
+	return b.ChatCompletionStreaming(ctx, req)
+}
+
+func (w *WeightedBackend) CompletionsStreaming(ctx context.Context, req map[string]interface{}) (*TextStreamReader, error) {
+	b, err := w.pick()
+	if err != nil {
+		return nil, err
+	}
+	return b.CompletionsStreaming(ctx, req)
+}
+
+func (w *WeightedBackend) ResponsesStreaming(ctx context.Context, req map[string]interface{}) (*TextStreamReader, error) {
+	b, err := w.pick()
+	if err != nil {
+		return nil, err
+	}
+	return b.ResponsesStreaming(ctx, req)
+}
+
+func (w *WeightedBackend) Embeddings(ctx context.Context, req *EmbeddingsRequest) (*EmbeddingsResponse, error) {
+	b, err := w.pick()
+	if err != nil {
+		return nil, err
+	}
+	return b.Embeddings(ctx, req)
+}
+
+func (w *WeightedBackend) GenerateVideo(ctx context.Context, req interface{}) (*VideoResponse, error) {
+	b, err := w.pick()
+	if err != nil {
+		return nil, err
+	}
+	return b.GenerateVideo(ctx, req)
+}
+
+// ==================== Per-Backend Rate Limiting ====================
+
+// RateLimitedBackend caps the rate of requests passed through to the
+// wrapped backend using a simple token bucket.
+type RateLimitedBackend struct {
+	backend Backend
+
+	mu     sync.Mutex
+	tokens float64
+	max    float64
+	rate   float64 // tokens replenished per second
+	last   time.Time
+}
+
+// NewRateLimitedBackend wraps backend with a token bucket allowing
+// requestsPerSecond sustained throughput and up to burst requests at once.
+func NewRateLimitedBackend(backend Backend, requestsPerSecond float64, burst int) *RateLimitedBackend {
+	return &RateLimitedBackend{
+		backend: backend,
+		tokens:  float64(burst),
+		max:     float64(burst),
+		rate:    requestsPerSecond,
+		last:    time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done
+func (r *RateLimitedBackend) wait(ctx context.Context) error {
+	for {
+		r.mu.Lock()
+		now := time.Now()
+		r.tokens = math.Min(r.max, r.tokens+now.Sub(r.last).Seconds()*r.rate)
+		r.last = now
+		if r.tokens >= 1 {
+			r.tokens--
+			r.mu.Unlock()
+			return nil
+		}
+		r.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func (r *RateLimitedBackend) ChatCompletion(ctx context.Context, req map[string]interface{}) (*TextResponse, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+	return r.backend.ChatCompletion(ctx, req)
+}
+
+func (r *RateLimitedBackend) Completions(ctx context.Context, req map[string]interface{}) (*TextResponse, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+	return r.backend.Completions(ctx, req)
+}
+
+func (r *RateLimitedBackend) Responses(ctx context.Context, req map[string]interface{}) (*TextResponse, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+	return r.backend.Responses(ctx, req)
+}
+
+func (r *RateLimitedBackend) ChatCompletionStreaming(ctx context.Context, req map[string]interface{}) (*TextStreamReader, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+	return r.backend.ChatCompletionStreaming(ctx, req)
+}
+
+func (r *RateLimitedBackend) CompletionsStreaming(ctx context.Context, req map[string]interface{}) (*TextStreamReader, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+	return r.backend.CompletionsStreaming(ctx, req)
+}
+
+func (r *RateLimitedBackend) ResponsesStreaming(ctx context.Context, req map[string]interface{}) (*TextStreamReader, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+	return r.backend.ResponsesStreaming(ctx, req)
+}
+
+func (r *RateLimitedBackend) Embeddings(ctx context.Context, req *EmbeddingsRequest) (*EmbeddingsResponse, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+	return r.backend.Embeddings(ctx, req)
+}
+
+func (r *RateLimitedBackend) GenerateVideo(ctx context.Context, req interface{}) (*VideoResponse, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+	return r.backend.GenerateVideo(ctx, req)
+}
@@ -0,0 +1,262 @@
+package onethingai
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BatchKind distinguishes the job type a BatchHandle was created for, since
+// image and video jobs are polled through different status endpoints.
+type BatchKind string
+
+const (
+	BatchKindImage BatchKind = "image"
+	BatchKindVideo BatchKind = "video"
+)
+
+// BatchHandle tracks a group of jobs submitted together via
+// GenerateImageBatch or GenerateVideoBatch. Jobs maps each server-assigned
+// JobID to the *ImageRequest or *VideoRequest (matching Kind) that
+// originated it, the same interface{}-plus-type-switch shape used by
+// validateImageRequest/validateVideoRequest elsewhere in this package.
+type BatchHandle struct {
+	BatchID string
+	Kind    BatchKind
+	Jobs    map[string]interface{}
+
+	Total     int
+	Completed int
+	Failed    int
+
+	mu        sync.Mutex
+	cancelled map[string]struct{}
+}
+
+// Cancel marks jobID as cancelled; PollBatch stops polling it on its next
+// tick and emits no further BatchEvents for it. It does not cancel the job
+// on the server.
+func (h *BatchHandle) Cancel(jobID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cancelled[jobID] = struct{}{}
+}
+
+// isCancelled reports whether jobID was passed to Cancel.
+func (h *BatchHandle) isCancelled(jobID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, ok := h.cancelled[jobID]
+	return ok
+}
+
+// recordOutcome increments Completed or Failed once, under the handle's lock.
+func (h *BatchHandle) recordOutcome(status Status) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	switch status {
+	case StatusSuccess:
+		h.Completed++
+	case StatusFailed:
+		h.Failed++
+	}
+}
+
+// BatchEvent reports one polling observation for one job in a batch.
+// Result holds *Result[ImageResult] or *Result[VideoResult] depending on
+// the originating BatchHandle.Kind, mirroring the any-typed Error field on
+// ImageAndVideoDataResponse.
+type BatchEvent struct {
+	JobID    string
+	Status   Status
+	Progress float64
+	Result   any
+	Err      error
+}
+
+// newBatchID returns a short random hex string identifying a batch in logs
+// and error messages.
+func newBatchID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
+
+// GenerateImageBatch submits reqs as individual async image jobs and
+// returns a BatchHandle keyed by the JobID each one was assigned. Poll the
+// batch with PollBatch.
+func (c *Client) GenerateImageBatch(ctx context.Context, reqs []*ImageRequest) (*BatchHandle, error) {
+	handle := &BatchHandle{
+		BatchID:   newBatchID(),
+		Kind:      BatchKindImage,
+		Jobs:      make(map[string]interface{}, len(reqs)),
+		Total:     len(reqs),
+		cancelled: make(map[string]struct{}),
+	}
+
+	for _, req := range reqs {
+		resp, err := c.SubmitImageJob(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("batch %s: failed to submit image job: %w", handle.BatchID, err)
+		}
+		handle.Jobs[resp.Data.JobID] = req
+	}
+
+	return handle, nil
+}
+
+// GenerateVideoBatch submits reqs as individual async video jobs and
+// returns a BatchHandle keyed by the JobID each one was assigned. Poll the
+// batch with PollBatch.
+func (c *Client) GenerateVideoBatch(ctx context.Context, reqs []*VideoRequest) (*BatchHandle, error) {
+	handle := &BatchHandle{
+		BatchID:   newBatchID(),
+		Kind:      BatchKindVideo,
+		Jobs:      make(map[string]interface{}, len(reqs)),
+		Total:     len(reqs),
+		cancelled: make(map[string]struct{}),
+	}
+
+	for _, req := range reqs {
+		resp, err := c.GenerateVideo(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("batch %s: failed to submit video job: %w", handle.BatchID, err)
+		}
+		handle.Jobs[resp.Data.JobID] = req
+	}
+
+	return handle, nil
+}
+
+// getBatchJobStatus fetches one job's status through the same
+// GetImageJobStatus/GetVideoJobStatus calls pollJobStatus drives, erasing
+// the image/video result type to any so PollBatch can multiplex both kinds
+// over a single BatchEvent channel.
+func (c *Client) getBatchJobStatus(ctx context.Context, kind BatchKind, jobID string) (Status, float64, any, error) {
+	if kind == BatchKindVideo {
+		resp, err := c.GetVideoJobStatus(ctx, jobID)
+		if err != nil {
+			return "", 0, nil, err
+		}
+		return resp.Data.Status, resp.Data.Progress, resp.Data.Result, nil
+	}
+
+	resp, err := c.GetImageJobStatus(ctx, jobID)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	return resp.Data.Status, resp.Data.Progress, resp.Data.Result, nil
+}
+
+// PollBatch polls every job in handle on a single shared ticker/timeout
+// instead of one goroutine-and-ticker pair per job, capping simultaneous
+// GetJobStatus calls at opts.MaxConcurrency (0 means no cap). It returns a
+// channel of BatchEvent, one per job per tick, closed once every job has
+// reached a terminal state, been cancelled via BatchHandle.Cancel, run out
+// of attempts, or the context/timeout fired. Use handle.Completed/Failed
+// for the running totals Done so far.
+func (c *Client) PollBatch(ctx context.Context, handle *BatchHandle, opts *PollingOptions) (<-chan BatchEvent, error) {
+	if handle == nil {
+		return nil, fmt.Errorf("batch handle cannot be nil")
+	}
+	if opts == nil {
+		opts = DefaultPollingOptions()
+	}
+
+	maxConcurrency := opts.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = len(handle.Jobs)
+	}
+	if maxConcurrency <= 0 {
+		maxConcurrency = 1
+	}
+
+	var cancel context.CancelFunc
+	if opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+	}
+
+	events := make(chan BatchEvent, len(handle.Jobs))
+	sem := make(chan struct{}, maxConcurrency)
+
+	pending := make(map[string]struct{}, len(handle.Jobs))
+	for jobID := range handle.Jobs {
+		pending[jobID] = struct{}{}
+	}
+
+	go func() {
+		defer close(events)
+		if cancel != nil {
+			defer cancel()
+		}
+
+		ticker := time.NewTicker(opts.Interval)
+		defer ticker.Stop()
+
+		attempt := 0
+		for len(pending) > 0 {
+			if opts.MaxAttempts > 0 && attempt >= opts.MaxAttempts {
+				for jobID := range pending {
+					events <- BatchEvent{JobID: jobID, Err: fmt.Errorf("max polling attempts (%d) exceeded", opts.MaxAttempts)}
+				}
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				for jobID := range pending {
+					events <- BatchEvent{JobID: jobID, Err: ctx.Err()}
+				}
+				return
+			case <-ticker.C:
+			}
+
+			jobIDs := make([]string, 0, len(pending))
+			for jobID := range pending {
+				jobIDs = append(jobIDs, jobID)
+			}
+
+			var wg sync.WaitGroup
+			var mu sync.Mutex
+			for _, jobID := range jobIDs {
+				if handle.isCancelled(jobID) {
+					mu.Lock()
+					delete(pending, jobID)
+					mu.Unlock()
+					continue
+				}
+
+				wg.Add(1)
+				sem <- struct{}{}
+				go func(jobID string) {
+					defer wg.Done()
+					defer func() { <-sem }()
+
+					status, progress, result, err := c.getBatchJobStatus(ctx, handle.Kind, jobID)
+					if err != nil {
+						events <- BatchEvent{JobID: jobID, Err: err}
+						return
+					}
+
+					events <- BatchEvent{JobID: jobID, Status: status, Progress: progress, Result: result}
+
+					if status == StatusSuccess || status == StatusFailed {
+						handle.recordOutcome(status)
+						mu.Lock()
+						delete(pending, jobID)
+						mu.Unlock()
+					}
+				}(jobID)
+			}
+			wg.Wait()
+			attempt++
+		}
+	}()
+
+	return events, nil
+}
@@ -0,0 +1,65 @@
+package onethingai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newPollBatchTestClient returns a Client whose GET /generation/job/{id}
+// calls are answered directly by srv's handler.
+func newPollBatchTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient("test-key", WithBaseURL(srv.URL), WithMaxRetries(0))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return c
+}
+
+// TestPollBatchConcurrentCancelNoRace exercises PollBatch with a large job
+// set, half of them cancelled up front, so the per-tick dispatch loop's
+// cancelled-path delete (on the main goroutine) and the completed-path
+// delete (from concurrently-running per-job goroutines) both hit the
+// pending map in the same tick. Run with -race to catch concurrent map
+// writes.
+func TestPollBatchConcurrentCancelNoRace(t *testing.T) {
+	const numJobs = 500
+
+	client := newPollBatchTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"code":0,"request_id":"r","data":{"job_id":"j","status":"success","progress":1}}`)
+	})
+
+	handle := &BatchHandle{
+		BatchID:   "test-batch",
+		Kind:      BatchKindImage,
+		Jobs:      make(map[string]interface{}, numJobs),
+		Total:     numJobs,
+		cancelled: make(map[string]struct{}),
+	}
+	for i := 0; i < numJobs; i++ {
+		jobID := fmt.Sprintf("job-%d", i)
+		handle.Jobs[jobID] = &ImageRequest{}
+		if i%2 == 0 {
+			handle.Cancel(jobID)
+		}
+	}
+
+	events, err := client.PollBatch(context.Background(), handle, &PollingOptions{
+		Interval:       10 * time.Millisecond,
+		Timeout:        10 * time.Second,
+		MaxConcurrency: 64,
+	})
+	if err != nil {
+		t.Fatalf("PollBatch: %v", err)
+	}
+
+	for range events {
+	}
+}
@@ -0,0 +1,195 @@
+package onethingai
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheEntry is what a Cache stores for one GET response.
+type CacheEntry struct {
+	StatusCode int
+	Headers    http.Header
+	Body       []byte
+	Expiry     time.Time
+}
+
+// Cache backs Transport's optional GET response cache, set via WithCache.
+// Implementations must be safe for concurrent use. The bundled LRUCache
+// covers the common in-process case; swap in a Redis-backed Cache to share
+// entries across processes.
+type Cache interface {
+	Get(ctx context.Context, key string) (*CacheEntry, bool)
+	Set(ctx context.Context, key string, entry *CacheEntry) error
+	Delete(ctx context.Context, key string) error
+	Purge(ctx context.Context) error
+}
+
+// LRUCache is an in-memory, capacity-bounded Cache evicting the
+// least-recently-used entry once full.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+// lruItem is the value stored in LRUCache.order's list.Element.
+type lruItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewLRUCache returns an LRUCache holding at most capacity entries (0 means
+// unbounded).
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (c *LRUCache) Get(_ context.Context, key string) (*CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*lruItem).entry, true
+}
+
+// Set implements Cache.
+func (c *LRUCache) Set(_ context.Context, key string, entry *CacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*lruItem).entry = entry
+		c.order.MoveToFront(el)
+		return nil
+	}
+
+	el := c.order.PushFront(&lruItem{key: key, entry: entry})
+	c.items[key] = el
+
+	if c.capacity > 0 && c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*lruItem).key)
+		}
+	}
+	return nil
+}
+
+// Delete implements Cache.
+func (c *LRUCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.order.Remove(el)
+		delete(c.items, key)
+	}
+	return nil
+}
+
+// Purge implements Cache.
+func (c *LRUCache) Purge(_ context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.items = make(map[string]*list.Element)
+	return nil
+}
+
+// cacheCtxKey namespaces the context values WithCacheTTL/WithNoCache attach.
+type cacheCtxKey int
+
+const (
+	cacheTTLCtxKey cacheCtxKey = iota
+	cacheNoCacheCtxKey
+)
+
+// WithCacheTTL returns a context that overrides Transport's default cache
+// TTL (and any Cache-Control/Expires response header) with ttl for calls
+// made with it.
+func WithCacheTTL(ctx context.Context, ttl time.Duration) context.Context {
+	return context.WithValue(ctx, cacheTTLCtxKey, ttl)
+}
+
+// WithNoCache returns a context that makes Transport skip both reading and
+// writing its GET response cache for calls made with it.
+func WithNoCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheNoCacheCtxKey, true)
+}
+
+// cacheTTLFromContext reports the per-call TTL override set by WithCacheTTL, if any.
+func cacheTTLFromContext(ctx context.Context) (time.Duration, bool) {
+	ttl, ok := ctx.Value(cacheTTLCtxKey).(time.Duration)
+	return ttl, ok
+}
+
+// cacheDisabled reports whether WithNoCache was applied to ctx.
+func cacheDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(cacheNoCacheCtxKey).(bool)
+	return disabled
+}
+
+// cacheKey identifies a cached GET response by method, URL, and a hash of
+// the (possibly per-request) Authorization header, so cached responses
+// aren't served across different credentials.
+func cacheKey(method, url, authHeader string) string {
+	sum := sha256.Sum256([]byte(authHeader))
+	return fmt.Sprintf("%s %s %s", method, url, hex.EncodeToString(sum[:8]))
+}
+
+// cacheTTL resolves how long a fresh GET response should be cached for:
+// a WithCacheTTL override takes precedence, then the response's
+// Cache-Control/Expires headers, then Transport's configured default.
+// A zero or negative result means "don't cache this response".
+func (t *Transport) cacheTTL(ctx context.Context, headers http.Header) time.Duration {
+	if ttl, ok := cacheTTLFromContext(ctx); ok {
+		return ttl
+	}
+
+	if cc := headers.Get("Cache-Control"); cc != "" {
+		for _, part := range strings.Split(cc, ",") {
+			part = strings.TrimSpace(part)
+			if part == "no-cache" || part == "no-store" {
+				return 0
+			}
+			if strings.HasPrefix(part, "max-age=") {
+				if secs, err := strconv.Atoi(strings.TrimPrefix(part, "max-age=")); err == nil {
+					return time.Duration(secs) * time.Second
+				}
+			}
+		}
+	}
+
+	if exp := headers.Get("Expires"); exp != "" {
+		when, err := http.ParseTime(exp)
+		if err != nil {
+			return t.cacheDefaultTTL
+		}
+		if d := time.Until(when); d > 0 {
+			return d
+		}
+		return 0
+	}
+
+	return t.cacheDefaultTTL
+}
@@ -0,0 +1,101 @@
+package onethingai
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestLRUCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRUCache(2)
+
+	c.Set(ctx, "a", &CacheEntry{StatusCode: 1})
+	c.Set(ctx, "b", &CacheEntry{StatusCode: 2})
+
+	// Touch "a" so "b" becomes the least-recently-used entry.
+	if _, ok := c.Get(ctx, "a"); !ok {
+		t.Fatal("expected a to be present")
+	}
+
+	c.Set(ctx, "c", &CacheEntry{StatusCode: 3})
+
+	if _, ok := c.Get(ctx, "b"); ok {
+		t.Error("expected b to have been evicted")
+	}
+	if _, ok := c.Get(ctx, "a"); !ok {
+		t.Error("expected a to still be present")
+	}
+	if _, ok := c.Get(ctx, "c"); !ok {
+		t.Error("expected c to be present")
+	}
+}
+
+func TestLRUCacheUnboundedWhenCapacityZero(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRUCache(0)
+
+	for i := 0; i < 100; i++ {
+		c.Set(ctx, string(rune('a'+i%26))+string(rune(i)), &CacheEntry{StatusCode: i})
+	}
+	if c.order.Len() != 100 {
+		t.Errorf("expected 100 entries with unbounded capacity, got %d", c.order.Len())
+	}
+}
+
+func TestLRUCacheDeleteAndPurge(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRUCache(10)
+	c.Set(ctx, "a", &CacheEntry{StatusCode: 1})
+	c.Set(ctx, "b", &CacheEntry{StatusCode: 2})
+
+	c.Delete(ctx, "a")
+	if _, ok := c.Get(ctx, "a"); ok {
+		t.Error("expected a to be deleted")
+	}
+
+	c.Purge(ctx)
+	if _, ok := c.Get(ctx, "b"); ok {
+		t.Error("expected b to be gone after purge")
+	}
+	if c.order.Len() != 0 {
+		t.Errorf("expected empty order list after purge, got %d", c.order.Len())
+	}
+}
+
+func TestCacheTTLMaxAge(t *testing.T) {
+	tr := &Transport{cacheDefaultTTL: time.Minute}
+	headers := http.Header{"Cache-Control": []string{"max-age=30"}}
+
+	got := tr.cacheTTL(context.Background(), headers)
+	if got != 30*time.Second {
+		t.Errorf("cacheTTL() = %v, want 30s", got)
+	}
+}
+
+func TestCacheTTLNoStoreIsZero(t *testing.T) {
+	tr := &Transport{cacheDefaultTTL: time.Minute}
+	headers := http.Header{"Cache-Control": []string{"no-store"}}
+
+	if got := tr.cacheTTL(context.Background(), headers); got != 0 {
+		t.Errorf("cacheTTL() = %v, want 0", got)
+	}
+}
+
+func TestCacheTTLContextOverrideTakesPrecedence(t *testing.T) {
+	tr := &Transport{cacheDefaultTTL: time.Minute}
+	headers := http.Header{"Cache-Control": []string{"max-age=30"}}
+	ctx := WithCacheTTL(context.Background(), 5*time.Second)
+
+	if got := tr.cacheTTL(ctx, headers); got != 5*time.Second {
+		t.Errorf("cacheTTL() = %v, want 5s override", got)
+	}
+}
+
+func TestCacheTTLFallsBackToDefault(t *testing.T) {
+	tr := &Transport{cacheDefaultTTL: 2 * time.Minute}
+	if got := tr.cacheTTL(context.Background(), http.Header{}); got != 2*time.Minute {
+		t.Errorf("cacheTTL() = %v, want default 2m", got)
+	}
+}
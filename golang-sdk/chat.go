@@ -0,0 +1,354 @@
+package onethingai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ==================== Typed Chat/Completion/Responses ====================
+//
+// These mirror the shapes used by mature OpenAI-compatible Go SDKs so callers
+// don't have to hand-assert their way through choices[0].message.content.
+// The map[string]interface{} based methods in text.go remain the primary
+// wire path; the typed structs here can be obtained from a response via
+// AsChatCompletion/AsCompletion/AsResponses (free functions, since
+// TextResponse aliases an instantiated generic type and can't carry new
+// methods), which round-trip the already decoded map through JSON so the
+// two representations never drift.
+
+// ChatMessage 聊天消息
+type ChatMessage struct {
+	Role       string         `json:"role"`
+	Content    MessageContent `json:"content"`
+	Name       string         `json:"name,omitempty"`
+	ToolCalls  []ToolCall     `json:"tool_calls,omitempty"`
+	ToolCallID string         `json:"tool_call_id,omitempty"`
+}
+
+// ContentImageURL 多模态消息中图片内容的 URL
+type ContentImageURL struct {
+	URL string `json:"url"`
+}
+
+// ContentPart 多模态消息内容中的一部分，Type 为 "text" 或 "image_url"
+type ContentPart struct {
+	Type     string           `json:"type"`
+	Text     string           `json:"text,omitempty"`
+	ImageURL *ContentImageURL `json:"image_url,omitempty"`
+}
+
+// MessageContent holds a ChatMessage's content, which the wire protocol
+// accepts as either a plain string or a multimodal []ContentPart, the same
+// union shape other OpenAI-compatible SDKs use.
+type MessageContent struct {
+	Text  string
+	Parts []ContentPart
+}
+
+// TextContent builds a plain-string MessageContent.
+func TextContent(text string) MessageContent {
+	return MessageContent{Text: text}
+}
+
+// MultimodalContent builds a MessageContent out of one or more ContentPart.
+func MultimodalContent(parts ...ContentPart) MessageContent {
+	return MessageContent{Parts: parts}
+}
+
+// MarshalJSON encodes Parts as a JSON array when set, otherwise Text as a
+// plain JSON string.
+func (c MessageContent) MarshalJSON() ([]byte, error) {
+	if c.Parts != nil {
+		return json.Marshal(c.Parts)
+	}
+	return json.Marshal(c.Text)
+}
+
+// UnmarshalJSON decodes either a plain string or a []ContentPart array into
+// MessageContent, so callers always get a single shape regardless of which
+// one the server sent.
+func (c *MessageContent) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		c.Text = text
+		c.Parts = nil
+		return nil
+	}
+
+	var parts []ContentPart
+	if err := json.Unmarshal(data, &parts); err != nil {
+		return fmt.Errorf("content is neither a string nor a content part array")
+	}
+	c.Parts = parts
+	c.Text = ""
+	return nil
+}
+
+// ToolCall 模型请求调用的工具
+type ToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+// Usage 令牌用量统计
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// Choice 单条补全结果
+type Choice struct {
+	Index        int          `json:"index"`
+	Message      *ChatMessage `json:"message,omitempty"`
+	Text         string       `json:"text,omitempty"`
+	Delta        *ChatMessage `json:"delta,omitempty"`
+	FinishReason string       `json:"finish_reason,omitempty"`
+}
+
+// StreamOptions 流式请求附加选项
+type StreamOptions struct {
+	// IncludeUsage asks the server to emit a final SSE chunk whose choices
+	// array is empty and whose usage field carries the token totals for
+	// the whole stream.
+	IncludeUsage bool `json:"include_usage"`
+}
+
+// ChatCompletionRequest 类型化的 chat/completions 请求
+type ChatCompletionRequest struct {
+	Model          string         `json:"model"`
+	Messages       []ChatMessage  `json:"messages"`
+	Temperature    *float64       `json:"temperature,omitempty"`
+	TopP           *float64       `json:"top_p,omitempty"`
+	MaxTokens      *int           `json:"max_tokens,omitempty"`
+	Stop           []string       `json:"stop,omitempty"`
+	Stream         *bool          `json:"stream,omitempty"`
+	StreamOptions  *StreamOptions `json:"stream_options,omitempty"`
+	Tools          []Tool         `json:"tools,omitempty"`
+	ToolChoice     interface{}    `json:"tool_choice,omitempty"`     // ToolChoiceMode or NamedToolChoice
+	ResponseFormat interface{}    `json:"response_format,omitempty"` // e.g. {"type": "json_object"} or {"type": "json_schema", ...}
+	Seed           *int64         `json:"seed,omitempty"`
+}
+
+// ChatCompletionResponse 类型化的 chat/completions 响应
+type ChatCompletionResponse struct {
+	ID      string   `json:"id"`
+	Object  string   `json:"object"`
+	Created int64    `json:"created"`
+	Model   string   `json:"model"`
+	Choices []Choice `json:"choices"`
+	Usage   *Usage   `json:"usage,omitempty"`
+}
+
+// CompletionRequest 类型化的 completions 请求
+type CompletionRequest struct {
+	Model       string   `json:"model"`
+	Prompt      string   `json:"prompt"`
+	Temperature *float64 `json:"temperature,omitempty"`
+	MaxTokens   *int     `json:"max_tokens,omitempty"`
+	Stream      *bool    `json:"stream,omitempty"`
+}
+
+// CompletionResponse 类型化的 completions 响应
+type CompletionResponse struct {
+	ID      string   `json:"id"`
+	Object  string   `json:"object"`
+	Created int64    `json:"created"`
+	Model   string   `json:"model"`
+	Choices []Choice `json:"choices"`
+	Usage   *Usage   `json:"usage,omitempty"`
+}
+
+// ResponsesRequest 类型化的 responses 请求
+type ResponsesRequest struct {
+	Model  string `json:"model"`
+	Input  string `json:"input"`
+	Stream *bool  `json:"stream,omitempty"`
+}
+
+// ResponsesResponse 类型化的 responses 响应
+type ResponsesResponse struct {
+	ID      string   `json:"id"`
+	Object  string   `json:"object"`
+	Created int64    `json:"created"`
+	Model   string   `json:"model"`
+	Choices []Choice `json:"choices"`
+	Usage   *Usage   `json:"usage,omitempty"`
+}
+
+// ChatCompletionChunk 类型化的流式 delta
+type ChatCompletionChunk struct {
+	ID      string   `json:"id"`
+	Object  string   `json:"object"`
+	Created int64    `json:"created"`
+	Model   string   `json:"model"`
+	Choices []Choice `json:"choices"`
+	Usage   *Usage   `json:"usage,omitempty"`
+}
+
+// asTyped round-trips a decoded TextDataResponse through JSON into a typed struct
+func asTyped[T any](data TextDataResponse) (*T, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var typed T
+	if err := json.Unmarshal(raw, &typed); err != nil {
+		return nil, err
+	}
+	return &typed, nil
+}
+
+// AsChatCompletion decodes the response data into a ChatCompletionResponse.
+// TextResponse is an alias for the generic Response[TextDataResponse]
+// instantiation, and Go doesn't allow methods on instantiated generic
+// types, so this is a free function rather than a method.
+func AsChatCompletion(r *TextResponse) (*ChatCompletionResponse, error) {
+	return asTyped[ChatCompletionResponse](r.Data)
+}
+
+// AsCompletion decodes the response data into a CompletionResponse
+func AsCompletion(r *TextResponse) (*CompletionResponse, error) {
+	return asTyped[CompletionResponse](r.Data)
+}
+
+// AsResponses decodes the response data into a ResponsesResponse
+func AsResponses(r *TextResponse) (*ResponsesResponse, error) {
+	return asTyped[ResponsesResponse](r.Data)
+}
+
+// NextTyped reads the next chunk from the text stream as a typed delta
+func (s *TextStreamReader) NextTyped() (*ChatCompletionChunk, error) {
+	raw, err := s.Next()
+	if err != nil {
+		return nil, err
+	}
+	return asTyped[ChatCompletionChunk](raw)
+}
+
+// StreamChatCompletion drives a TextStreamReader to completion, invoking
+// onDelta for every chunk (including a final usage-only chunk with no
+// choices) and, once the stream ends, onUsage with
+// the aggregated token usage (nil if the server didn't send one, e.g.
+// stream_options.include_usage wasn't set). This lets callers bill tokens
+// for a streamed chat completion without parsing raw chunks themselves.
+func StreamChatCompletion(ctx context.Context, reader *TextStreamReader, onDelta func(*ChatCompletionChunk) error, onUsage func(*Usage)) error {
+	defer reader.Close()
+
+	for {
+		chunk, err := reader.NextTyped()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+
+		if onDelta != nil {
+			if err := onDelta(chunk); err != nil {
+				return err
+			}
+		}
+	}
+
+	if onUsage != nil {
+		onUsage(reader.Usage())
+	}
+
+	return nil
+}
+
+// reqToMap round-trips a typed request struct through JSON into a
+// map[string]interface{} so it can be routed through the existing
+// map-based methods without duplicating the wire-protocol logic.
+func reqToMap[T any](req T) (map[string]interface{}, error) {
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ChatCompletionTyped is ChatCompletion for callers who'd rather build a
+// ChatCompletionRequest than a map[string]interface{}; the struct is
+// marshaled to a map and sent over the same wire path.
+func (c *Client) ChatCompletionTyped(ctx context.Context, req *ChatCompletionRequest) (*ChatCompletionResponse, error) {
+	reqMap, err := reqToMap(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal chat completion request: %w", err)
+	}
+	resp, err := c.ChatCompletion(ctx, reqMap)
+	if err != nil {
+		return nil, err
+	}
+	return AsChatCompletion(resp)
+}
+
+// CompletionsTyped is Completions for callers who'd rather build a
+// CompletionRequest than a map[string]interface{}.
+func (c *Client) CompletionsTyped(ctx context.Context, req *CompletionRequest) (*CompletionResponse, error) {
+	reqMap, err := reqToMap(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal completion request: %w", err)
+	}
+	resp, err := c.Completions(ctx, reqMap)
+	if err != nil {
+		return nil, err
+	}
+	return AsCompletion(resp)
+}
+
+// ResponsesTyped is Responses for callers who'd rather build a
+// ResponsesRequest than a map[string]interface{}.
+func (c *Client) ResponsesTyped(ctx context.Context, req *ResponsesRequest) (*ResponsesResponse, error) {
+	reqMap, err := reqToMap(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal responses request: %w", err)
+	}
+	resp, err := c.Responses(ctx, reqMap)
+	if err != nil {
+		return nil, err
+	}
+	return AsResponses(resp)
+}
+
+// ChatCompletionStreamingTyped is ChatCompletionStreaming for callers who'd
+// rather build a ChatCompletionRequest than a map[string]interface{}; read
+// typed deltas back off the returned reader with NextTyped.
+func (c *Client) ChatCompletionStreamingTyped(ctx context.Context, req *ChatCompletionRequest) (*TextStreamReader, error) {
+	reqMap, err := reqToMap(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal chat completion request: %w", err)
+	}
+	return c.ChatCompletionStreaming(ctx, reqMap)
+}
+
+// CompletionsStreamingTyped is CompletionsStreaming for callers who'd
+// rather build a CompletionRequest than a map[string]interface{}.
+func (c *Client) CompletionsStreamingTyped(ctx context.Context, req *CompletionRequest) (*TextStreamReader, error) {
+	reqMap, err := reqToMap(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal completion request: %w", err)
+	}
+	return c.CompletionsStreaming(ctx, reqMap)
+}
+
+// ResponsesStreamingTyped is ResponsesStreaming for callers who'd rather
+// build a ResponsesRequest than a map[string]interface{}.
+func (c *Client) ResponsesStreamingTyped(ctx context.Context, req *ResponsesRequest) (*TextStreamReader, error) {
+	reqMap, err := reqToMap(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal responses request: %w", err)
+	}
+	return c.ResponsesStreaming(ctx, reqMap)
+}
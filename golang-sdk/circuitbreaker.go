@@ -0,0 +1,196 @@
+package onethingai
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a circuitBreaker, returned by
+// Client.CircuitState for observability.
+type CircuitState int
+
+const (
+	// CircuitClosed is the normal state: requests pass through and are
+	// counted toward the failure ratio.
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen rejects every request with ErrCircuitOpen without
+	// touching the network, until OpenTimeout elapses.
+	CircuitOpen
+
+	// CircuitHalfOpen allows up to HalfOpenProbes requests through to
+	// test whether the backend has recovered.
+	CircuitHalfOpen
+)
+
+// String renders the state the way it's reported by Client.CircuitState.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig configures the circuit breaker installed by
+// WithCircuitBreaker.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is how many requests the closed-state window
+	// samples before the failure ratio is evaluated; the window then
+	// resets for the next batch.
+	FailureThreshold int
+
+	// FailureRatio is the fraction of failing requests (0.0-1.0) within
+	// a FailureThreshold-sized window that trips the breaker open.
+	FailureRatio float64
+
+	// OpenTimeout is how long the breaker stays open before allowing
+	// HalfOpenProbes requests through to test recovery.
+	OpenTimeout time.Duration
+
+	// HalfOpenProbes is how many consecutive successful half-open
+	// requests are required to close the breaker again; a single failure
+	// re-opens it immediately.
+	HalfOpenProbes int
+}
+
+// DefaultCircuitBreakerConfig returns a reasonable default: a 10-request
+// window, 50% failure ratio, 30s open timeout, and 3 half-open probes.
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		FailureThreshold: 10,
+		FailureRatio:     0.5,
+		OpenTimeout:      30 * time.Second,
+		HalfOpenProbes:   3,
+	}
+}
+
+// circuitBreaker tracks one backend's health across DoRequest calls and
+// decides whether a new call may proceed. It isn't a free-standing rolling
+// window: the closed-state counters reset every FailureThreshold requests
+// (a tumbling window), which is simpler than true sliding-window accounting
+// and sufficient for deciding "is this backend currently unhealthy".
+type circuitBreaker struct {
+	cfg CircuitBreakerConfig
+
+	mu                sync.Mutex
+	state             CircuitState
+	requests          int
+	failures          int
+	openedAt          time.Time
+	halfOpenProbes    int
+	halfOpenSuccesses int
+}
+
+// newCircuitBreaker returns a closed circuitBreaker configured by cfg.
+func newCircuitBreaker(cfg CircuitBreakerConfig) *circuitBreaker {
+	return &circuitBreaker{cfg: cfg}
+}
+
+// allow reports whether a new request may proceed, transitioning an open
+// breaker to half-open once cfg.OpenTimeout has elapsed.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if time.Since(b.openedAt) < b.cfg.OpenTimeout {
+			return false
+		}
+		b.state = CircuitHalfOpen
+		b.halfOpenProbes = 0
+		b.halfOpenSuccesses = 0
+	case CircuitHalfOpen:
+		if b.halfOpenProbes >= b.cfg.HalfOpenProbes {
+			return false
+		}
+	}
+
+	if b.state == CircuitHalfOpen {
+		b.halfOpenProbes++
+	}
+	return true
+}
+
+// trip transitions the breaker to open; callers must hold b.mu.
+func (b *circuitBreaker) trip() {
+	b.state = CircuitOpen
+	b.openedAt = time.Now()
+	b.requests = 0
+	b.failures = 0
+	b.halfOpenProbes = 0
+	b.halfOpenSuccesses = 0
+}
+
+// recordSuccess reports a successful request.
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitHalfOpen:
+		b.halfOpenSuccesses++
+		if b.halfOpenSuccesses >= b.cfg.HalfOpenProbes {
+			b.state = CircuitClosed
+			b.requests = 0
+			b.failures = 0
+		}
+	case CircuitClosed:
+		b.requests++
+		if b.requests >= b.cfg.FailureThreshold {
+			b.requests = 0
+			b.failures = 0
+		}
+	}
+}
+
+// recordFailure reports a failed request.
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.trip()
+		return
+	}
+
+	b.requests++
+	b.failures++
+	if b.requests >= b.cfg.FailureThreshold {
+		if float64(b.failures)/float64(b.requests) >= b.cfg.FailureRatio {
+			b.trip()
+			return
+		}
+		b.requests = 0
+		b.failures = 0
+	}
+}
+
+// State reports the breaker's current state.
+func (b *circuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// isCircuitFailure reports whether err should count against the circuit
+// breaker: 5xx responses, network-level errors, and context deadlines. 4xx
+// responses (including the 408/429 that DoRequest retries) reflect the
+// caller or the request, not backend health, so they're excluded.
+func isCircuitFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+package onethingai
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func testBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		FailureThreshold: 4,
+		FailureRatio:     0.5,
+		OpenTimeout:      20 * time.Millisecond,
+		HalfOpenProbes:   2,
+	}
+}
+
+func TestCircuitBreakerTripsAtFailureRatio(t *testing.T) {
+	b := newCircuitBreaker(testBreakerConfig())
+
+	b.recordSuccess()
+	b.recordFailure()
+	b.recordFailure() // 2/3 failing, threshold of 4 not yet reached
+	if b.State() != CircuitClosed {
+		t.Fatalf("state = %v, want closed before the window fills", b.State())
+	}
+
+	b.recordFailure() // 3/4 >= 0.5 ratio, window now full
+	if b.State() != CircuitOpen {
+		t.Fatalf("state = %v, want open once the ratio is exceeded", b.State())
+	}
+	if b.allow() {
+		t.Error("allow() = true, want false while open")
+	}
+}
+
+func TestCircuitBreakerResetsWindowBelowRatio(t *testing.T) {
+	b := newCircuitBreaker(testBreakerConfig())
+
+	b.recordSuccess()
+	b.recordSuccess()
+	b.recordSuccess()
+	b.recordFailure() // 1/4, below the 0.5 ratio: window resets, stays closed
+	if b.State() != CircuitClosed {
+		t.Fatalf("state = %v, want closed", b.State())
+	}
+	if !b.allow() {
+		t.Error("allow() = false, want true while closed")
+	}
+}
+
+func TestCircuitBreakerHalfOpenTransitionAndRecovery(t *testing.T) {
+	cfg := testBreakerConfig()
+	b := newCircuitBreaker(cfg)
+
+	for i := 0; i < cfg.FailureThreshold; i++ {
+		b.recordFailure()
+	}
+	if b.State() != CircuitOpen {
+		t.Fatalf("state = %v, want open", b.State())
+	}
+
+	time.Sleep(cfg.OpenTimeout + 5*time.Millisecond)
+
+	if !b.allow() {
+		t.Fatal("allow() = false, want true once OpenTimeout elapses")
+	}
+	if b.State() != CircuitHalfOpen {
+		t.Fatalf("state = %v, want half_open", b.State())
+	}
+
+	for i := 0; i < cfg.HalfOpenProbes; i++ {
+		b.recordSuccess()
+	}
+	if b.State() != CircuitClosed {
+		t.Fatalf("state = %v, want closed after %d successful probes", b.State(), cfg.HalfOpenProbes)
+	}
+}
+
+func TestCircuitBreakerHalfOpenFailureReopens(t *testing.T) {
+	cfg := testBreakerConfig()
+	b := newCircuitBreaker(cfg)
+
+	for i := 0; i < cfg.FailureThreshold; i++ {
+		b.recordFailure()
+	}
+	time.Sleep(cfg.OpenTimeout + 5*time.Millisecond)
+	if !b.allow() {
+		t.Fatal("expected half-open probe to be allowed")
+	}
+
+	b.recordFailure()
+	if b.State() != CircuitOpen {
+		t.Fatalf("state = %v, want open again after a half-open failure", b.State())
+	}
+}
+
+func TestCircuitBreakerHalfOpenLimitsProbes(t *testing.T) {
+	cfg := testBreakerConfig()
+	b := newCircuitBreaker(cfg)
+
+	for i := 0; i < cfg.FailureThreshold; i++ {
+		b.recordFailure()
+	}
+	time.Sleep(cfg.OpenTimeout + 5*time.Millisecond)
+
+	for i := 0; i < cfg.HalfOpenProbes; i++ {
+		if !b.allow() {
+			t.Fatalf("probe %d: allow() = false, want true", i)
+		}
+	}
+	if b.allow() {
+		t.Error("allow() = true, want false once HalfOpenProbes are in flight")
+	}
+}
+
+func TestIsCircuitFailure(t *testing.T) {
+	if isCircuitFailure(nil) {
+		t.Error("isCircuitFailure(nil) = true, want false")
+	}
+	if !isCircuitFailure(errors.New("boom")) {
+		t.Error("isCircuitFailure(generic error) = false, want true")
+	}
+	if isCircuitFailure(&HTTPError{StatusCode: 429}) {
+		t.Error("isCircuitFailure(429) = true, want false (caller/request issue, not backend health)")
+	}
+	if !isCircuitFailure(&HTTPError{StatusCode: 503}) {
+		t.Error("isCircuitFailure(503) = false, want true")
+	}
+}
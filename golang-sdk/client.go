@@ -2,15 +2,21 @@ package onethingai
 
 import (
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"time"
+
+	"golang.org/x/time/rate"
 )
 
 const (
-	DefaultBaseURL       = "https://api-model.onethingai.com/v2"
-	DefaultTimeout       = 60 * time.Second
-	DefaultMaxRetries    = 3
-	DefaultPollingPeriod = 2 * time.Second
+	DefaultBaseURL             = "https://api-model.onethingai.com/v2"
+	DefaultTimeout             = 60 * time.Second
+	DefaultMaxRetries          = 3
+	DefaultPollingPeriod       = 2 * time.Second
+	DefaultEmbeddingBatchSize  = 100
+	DefaultMaxInlineVideoBytes = 50 * 1024 * 1024
 )
 
 // Client is the main SDK client
@@ -41,6 +47,76 @@ type Config struct {
 
 	// Custom headers
 	Headers map[string]string
+
+	// EmbeddingBatchSize caps how many inputs EmbedTexts puts into a single
+	// /embeddings call before chunking into parallel requests
+	EmbeddingBatchSize int
+
+	// Router dispatches requests to alternate Backends by model, set via
+	// WithBackend. A nil Router means every request goes through this
+	// Client's own HTTP implementation.
+	Router *Router
+
+	// Logger receives a structured event for every request and response,
+	// set via WithLogger. A nil Logger means logging is a no-op.
+	Logger Logger
+
+	// RequestDump, if set via WithRequestDump, receives the full,
+	// unredacted request and response bodies. Local debugging only.
+	RequestDump io.Writer
+
+	// Moderator reviews generated images/videos, set via WithModerator. A
+	// nil Moderator means no review happens.
+	Moderator Moderator
+
+	// ModerationPolicy decides what happens to a failed Moderator verdict;
+	// see WithModerator.
+	ModerationPolicy ModerationPolicy
+
+	// RateLimiter caps outgoing request throughput, set via WithRateLimit.
+	// A nil RateLimiter means requests are not locally throttled.
+	RateLimiter *rate.Limiter
+
+	// RetryPolicy overrides the default exponential-backoff retry
+	// schedule, set via WithRetryPolicy. A nil RetryPolicy means
+	// DefaultRetryPolicy with MaxRetries taken from the MaxRetries field
+	// above.
+	RetryPolicy *RetryPolicy
+
+	// OnRequest, if set via WithRequestHook, is called for every HTTP
+	// attempt right before it's sent.
+	OnRequest RequestHookFunc
+
+	// OnResponse, if set via WithResponseHook, is called for every HTTP
+	// attempt right after it completes.
+	OnResponse ResponseHookFunc
+
+	// OnRetry, if set via WithRetryHook, is called before DoRequest sleeps
+	// and retries a failed attempt.
+	OnRetry RetryHookFunc
+
+	// OnError, if set via WithErrorHook, is called once DoRequest gives up
+	// on a request, with the final error.
+	OnError ErrorHookFunc
+
+	// Authenticator applies credentials to every outgoing request, set via
+	// WithAuthenticator. A nil Authenticator means BearerTokenAuth{Token:
+	// APIKey}, matching the SDK's historical hardcoded behavior.
+	Authenticator Authenticator
+
+	// Cache backs an optional GET response cache, set via WithCache. A nil
+	// Cache means GET requests are never cached.
+	Cache Cache
+
+	// CacheDefaultTTL is how long a GET response is cached for when the
+	// response carries no Cache-Control/Expires header and no per-call
+	// WithCacheTTL override applies; see WithCache.
+	CacheDefaultTTL time.Duration
+
+	// CircuitBreaker configures a per-Transport circuit breaker, set via
+	// WithCircuitBreaker. A nil CircuitBreaker means DoRequest never
+	// short-circuits on its own.
+	CircuitBreaker *CircuitBreakerConfig
 }
 
 // NewClient creates a new OneThing AI SDK client
@@ -50,12 +126,13 @@ func NewClient(apiKey string, opts ...ClientOption) (*Client, error) {
 	}
 
 	config := &Config{
-		APIKey:        apiKey,
-		BaseURL:       DefaultBaseURL,
-		Timeout:       DefaultTimeout,
-		MaxRetries:    DefaultMaxRetries,
-		PollingPeriod: DefaultPollingPeriod,
-		Headers:       make(map[string]string),
+		APIKey:             apiKey,
+		BaseURL:            DefaultBaseURL,
+		Timeout:            DefaultTimeout,
+		MaxRetries:         DefaultMaxRetries,
+		PollingPeriod:      DefaultPollingPeriod,
+		Headers:            make(map[string]string),
+		EmbeddingBatchSize: DefaultEmbeddingBatchSize,
 	}
 
 	// Apply options
@@ -118,6 +195,141 @@ func WithPollingPeriod(period time.Duration) ClientOption {
 	}
 }
 
+// WithEmbeddingBatchSize sets how many inputs EmbedTexts batches per request
+func WithEmbeddingBatchSize(size int) ClientOption {
+	return func(c *Config) {
+		c.EmbeddingBatchSize = size
+	}
+}
+
+// WithBackend registers backend to serve requests for the given model name.
+// client.ChatCompletion/Completions/Responses/Embeddings/GenerateVideo
+// transparently dispatch to it instead of this client's own HTTP path once
+// at least one model is registered; see Router for prefix/predicate routing.
+func WithBackend(model string, backend Backend) ClientOption {
+	return func(c *Config) {
+		if c.Router == nil {
+			c.Router = NewRouter()
+		}
+		c.Router.Register(model, backend)
+	}
+}
+
+// WithLogger wires l into the client as the structured Logger backing every
+// request/response event; see Logger for the fields it emits.
+func WithLogger(l *slog.Logger) ClientOption {
+	return func(c *Config) {
+		c.Logger = &slogLogger{logger: l}
+	}
+}
+
+// WithRequestDump writes the full, unredacted request and response bodies
+// to w for local debugging. Unlike the structured Logger, this does not
+// truncate B64JSON fields or redact credentials, so avoid it in production.
+func WithRequestDump(w io.Writer) ClientOption {
+	return func(c *Config) {
+		c.RequestDump = w
+	}
+}
+
+// WithRateLimit caps outgoing requests to rps requests/second with a burst
+// of up to burst, via golang.org/x/time/rate. doRequestOnce and
+// DoStreamRequest block on the limiter before issuing each HTTP call, and a
+// 429 response additionally decays it to the server-advertised Retry-After
+// rate for a cool-down window; see Transport.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return func(c *Config) {
+		c.RateLimiter = rate.NewLimiter(rate.Limit(rps), burst)
+	}
+}
+
+// WithRetryPolicy overrides the default retry schedule (DefaultRetryPolicy
+// with MaxRetries taken from WithMaxRetries); see RetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Config) {
+		c.RetryPolicy = &policy
+	}
+}
+
+// WithRequestHook registers fn to be called for every HTTP attempt right
+// before it's sent; see RequestLog.
+func WithRequestHook(fn RequestHookFunc) ClientOption {
+	return func(c *Config) {
+		c.OnRequest = fn
+	}
+}
+
+// WithResponseHook registers fn to be called for every HTTP attempt right
+// after it completes, successfully or not; see ResponseLog.
+func WithResponseHook(fn ResponseHookFunc) ClientOption {
+	return func(c *Config) {
+		c.OnResponse = fn
+	}
+}
+
+// WithRetryHook registers fn to be called before DoRequest sleeps and
+// retries a failed attempt.
+func WithRetryHook(fn RetryHookFunc) ClientOption {
+	return func(c *Config) {
+		c.OnRetry = fn
+	}
+}
+
+// WithErrorHook registers fn to be called once DoRequest gives up on a
+// request, with the final error.
+func WithErrorHook(fn ErrorHookFunc) ClientOption {
+	return func(c *Config) {
+		c.OnError = fn
+	}
+}
+
+// WithDebugLogger wires a NewDebugLogger writing to w into all four
+// request/response/retry/error hooks at once, for quick local
+// troubleshooting (including of streaming image generation) without
+// implementing the hook types by hand.
+func WithDebugLogger(w io.Writer) ClientOption {
+	return func(c *Config) {
+		d := NewDebugLogger(w)
+		c.OnRequest = d.OnRequest
+		c.OnResponse = d.OnResponse
+		c.OnRetry = d.OnRetry
+		c.OnError = d.OnError
+	}
+}
+
+// WithAuthenticator overrides the default BearerTokenAuth with a custom
+// Authenticator, so requests can carry a static header, an HMAC-signed
+// header, or a transparently-refreshed short-lived token instead of the
+// API key passed to NewClient; see Authenticator.
+func WithAuthenticator(a Authenticator) ClientOption {
+	return func(c *Config) {
+		c.Authenticator = a
+	}
+}
+
+// WithCache enables Transport's GET response cache, backed by store (use
+// NewLRUCache for the bundled in-memory implementation, or a custom Cache
+// to share entries across processes). defaultTTL is used for responses
+// that don't carry a Cache-Control/Expires header and aren't overridden by
+// WithCacheTTL; see doRequestOnce.
+func WithCache(store Cache, defaultTTL time.Duration) ClientOption {
+	return func(c *Config) {
+		c.Cache = store
+		c.CacheDefaultTTL = defaultTTL
+	}
+}
+
+// WithCircuitBreaker installs a per-Transport circuit breaker configured by
+// cfg. Once the failure ratio over a FailureThreshold-sized window of
+// DoRequest calls reaches cfg.FailureRatio, the breaker opens and DoRequest
+// returns ErrCircuitOpen immediately for cfg.OpenTimeout, instead of
+// hitting a backend that's already failing; see CircuitBreakerConfig.
+func WithCircuitBreaker(cfg CircuitBreakerConfig) ClientOption {
+	return func(c *Config) {
+		c.CircuitBreaker = &cfg
+	}
+}
+
 // WithHeader adds a custom header
 func WithHeader(key, value string) ClientOption {
 	return func(c *Config) {
@@ -130,6 +342,12 @@ func (c *Client) GetConfig() *Config {
 	return c.config
 }
 
+// CircuitState reports the current state of the circuit breaker installed
+// via WithCircuitBreaker, or CircuitClosed if none was configured.
+func (c *Client) CircuitState() CircuitState {
+	return c.transport.circuitState()
+}
+
 // Close closes the client and cleans up resources
 func (c *Client) Close() error {
 	// Transport cleanup is handled by the HTTP client
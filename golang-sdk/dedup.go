@@ -0,0 +1,225 @@
+package onethingai
+
+import (
+	"context"
+	"image"
+	"math"
+	"math/bits"
+	"sort"
+
+	"golang.org/x/image/draw"
+)
+
+// DefaultDedupeThreshold is the Hamming distance, in bits, at or below which
+// two perceptual hashes are considered near-duplicates by DedupeImages and
+// DedupingStreamReader.
+const DefaultDedupeThreshold = 5
+
+const phashSize = 32
+const phashBlockSize = 8
+
+// PerceptualHash computes a 64-bit pHash fingerprint for the result: decode
+// (fetching the URL if needed), resize to 32x32 grayscale, run a 2-D DCT,
+// and threshold the top-left 8x8 block (excluding the DC term) against its
+// median. Near-duplicate images produce hashes with a small Hamming
+// distance; see DedupeImages.
+func (r *ImageResult) PerceptualHash() (uint64, error) {
+	img, err := r.Decode(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	return perceptualHash(img)
+}
+
+// perceptualHash implements the pHash algorithm described on PerceptualHash.
+func perceptualHash(img image.Image) (uint64, error) {
+	gray := image.NewGray(image.Rect(0, 0, phashSize, phashSize))
+	draw.BiLinear.Scale(gray, gray.Bounds(), img, img.Bounds(), draw.Over, nil)
+
+	matrix := make([][]float64, phashSize)
+	for y := 0; y < phashSize; y++ {
+		matrix[y] = make([]float64, phashSize)
+		for x := 0; x < phashSize; x++ {
+			matrix[y][x] = float64(gray.GrayAt(x, y).Y)
+		}
+	}
+
+	coeffs := dct2D(matrix)
+
+	block := make([]float64, 0, phashBlockSize*phashBlockSize-1)
+	for y := 0; y < phashBlockSize; y++ {
+		for x := 0; x < phashBlockSize; x++ {
+			if x == 0 && y == 0 {
+				continue // exclude the DC term
+			}
+			block = append(block, coeffs[y][x])
+		}
+	}
+	median := medianOf(block)
+
+	var hash uint64
+	var bit uint
+	for y := 0; y < phashBlockSize; y++ {
+		for x := 0; x < phashBlockSize; x++ {
+			if x == 0 && y == 0 {
+				continue
+			}
+			if coeffs[y][x] > median {
+				hash |= 1 << bit
+			}
+			bit++
+		}
+	}
+	return hash, nil
+}
+
+// dct1D computes the 1-D DCT-II of input, orthonormalized so dct2D can be
+// built by applying it to rows then columns.
+func dct1D(input []float64) []float64 {
+	n := len(input)
+	output := make([]float64, n)
+	for u := 0; u < n; u++ {
+		var sum float64
+		for x := 0; x < n; x++ {
+			sum += input[x] * math.Cos(math.Pi/float64(n)*(float64(x)+0.5)*float64(u))
+		}
+		cu := 1.0
+		if u == 0 {
+			cu = 1 / math.Sqrt2
+		}
+		output[u] = sum * cu * math.Sqrt(2.0/float64(n))
+	}
+	return output
+}
+
+// dct2D computes a 2-D DCT-II of a square matrix as two passes of dct1D, one
+// over rows and one over columns.
+func dct2D(matrix [][]float64) [][]float64 {
+	n := len(matrix)
+
+	rows := make([][]float64, n)
+	for i := range matrix {
+		rows[i] = dct1D(matrix[i])
+	}
+
+	result := make([][]float64, n)
+	for i := range result {
+		result[i] = make([]float64, n)
+	}
+	for x := 0; x < n; x++ {
+		col := make([]float64, n)
+		for y := 0; y < n; y++ {
+			col[y] = rows[y][x]
+		}
+		col = dct1D(col)
+		for y := 0; y < n; y++ {
+			result[y][x] = col[y]
+		}
+	}
+	return result
+}
+
+// medianOf returns the median of values without mutating the input slice.
+func medianOf(values []float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// hammingDistance returns the number of differing bits between two hashes.
+func hammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// DedupeImages drops results whose perceptual hash is within threshold
+// Hamming-distance bits of one already kept (DefaultDedupeThreshold if
+// threshold <= 0). Results that fail to hash (e.g. their URL can't be
+// fetched) are kept rather than risk dropping a unique image.
+func DedupeImages(results []ImageResult, threshold int) []ImageResult {
+	if threshold <= 0 {
+		threshold = DefaultDedupeThreshold
+	}
+
+	kept := make([]ImageResult, 0, len(results))
+	hashes := make([]uint64, 0, len(results))
+
+	for _, r := range results {
+		hash, err := r.PerceptualHash()
+		if err != nil {
+			kept = append(kept, r)
+			continue
+		}
+
+		if isDuplicateHash(hash, hashes, threshold) {
+			continue
+		}
+		kept = append(kept, r)
+		hashes = append(hashes, hash)
+	}
+
+	return kept
+}
+
+func isDuplicateHash(hash uint64, seen []uint64, threshold int) bool {
+	for _, h := range seen {
+		if hammingDistance(hash, h) <= threshold {
+			return true
+		}
+	}
+	return false
+}
+
+// DedupingStreamReader wraps a StreamReader[ImageResult], silently skipping
+// partial results whose perceptual hash is a near-duplicate of one already
+// emitted. Non-partial-result events (progress, error, done) pass through
+// unchanged.
+type DedupingStreamReader struct {
+	inner     *StreamReader[ImageResult]
+	threshold int
+	hashes    []uint64
+}
+
+// NewDedupingStreamReader wraps inner, dropping partial results within
+// threshold Hamming-distance bits of one already kept (DefaultDedupeThreshold
+// if threshold <= 0).
+func NewDedupingStreamReader(inner *StreamReader[ImageResult], threshold int) *DedupingStreamReader {
+	if threshold <= 0 {
+		threshold = DefaultDedupeThreshold
+	}
+	return &DedupingStreamReader{inner: inner, threshold: threshold}
+}
+
+// Next returns the next non-duplicate event from the stream.
+func (d *DedupingStreamReader) Next() (*StreamDataResponse[ImageResult], error) {
+	for {
+		event, err := d.inner.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !event.IsPartialResult() {
+			return event, nil
+		}
+
+		hash, err := event.Data.PerceptualHash()
+		if err != nil {
+			return event, nil
+		}
+
+		if isDuplicateHash(hash, d.hashes, d.threshold) {
+			continue
+		}
+		d.hashes = append(d.hashes, hash)
+		return event, nil
+	}
+}
+
+// Close closes the underlying stream.
+func (d *DedupingStreamReader) Close() error {
+	return d.inner.Close()
+}
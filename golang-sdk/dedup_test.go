@@ -0,0 +1,87 @@
+package onethingai
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHammingDistance(t *testing.T) {
+	cases := []struct {
+		a, b uint64
+		want int
+	}{
+		{0, 0, 0},
+		{0, 1, 1},
+		{0, 0xFF, 8},
+		{0xFFFFFFFFFFFFFFFF, 0, 64},
+	}
+	for _, c := range cases {
+		if got := hammingDistance(c.a, c.b); got != c.want {
+			t.Errorf("hammingDistance(%x, %x) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestIsDuplicateHash(t *testing.T) {
+	seen := []uint64{0b0000}
+	if !isDuplicateHash(0b0001, seen, 1) {
+		t.Error("expected hash within threshold to be a duplicate")
+	}
+	if isDuplicateHash(0b0111, seen, 1) {
+		t.Error("expected hash outside threshold to not be a duplicate")
+	}
+}
+
+func TestMedianOf(t *testing.T) {
+	cases := []struct {
+		values []float64
+		want   float64
+	}{
+		{[]float64{1, 2, 3}, 2},
+		{[]float64{1, 2, 3, 4}, 2.5},
+		{[]float64{5}, 5},
+	}
+	for _, c := range cases {
+		got := medianOf(c.values)
+		if got != c.want {
+			t.Errorf("medianOf(%v) = %v, want %v", c.values, got, c.want)
+		}
+	}
+}
+
+func TestMedianOfDoesNotMutateInput(t *testing.T) {
+	values := []float64{3, 1, 2}
+	_ = medianOf(values)
+	if values[0] != 3 || values[1] != 1 || values[2] != 2 {
+		t.Errorf("medianOf mutated its input: %v", values)
+	}
+}
+
+func TestDCT2DConstantInputProducesZeroACCoefficients(t *testing.T) {
+	n := 8
+	matrix := make([][]float64, n)
+	for y := range matrix {
+		matrix[y] = make([]float64, n)
+		for x := range matrix[y] {
+			matrix[y][x] = 100
+		}
+	}
+
+	coeffs := dct2D(matrix)
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			if x == 0 && y == 0 {
+				continue
+			}
+			if math.Abs(coeffs[y][x]) > 1e-9 {
+				t.Errorf("dct2D(%d,%d) = %v, want ~0 for a constant input", y, x, coeffs[y][x])
+			}
+		}
+	}
+}
+
+func TestIsDuplicateHashEmptySeen(t *testing.T) {
+	if isDuplicateHash(0x1234, nil, 5) {
+		t.Error("expected no duplicate against an empty seen list")
+	}
+}
@@ -0,0 +1,295 @@
+package onethingai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDownloadConcurrency is the number of URLs DownloadResults fetches
+// in parallel when DownloadOptions.Concurrency is left at zero.
+const DefaultDownloadConcurrency = 4
+
+// DefaultDownloadRetryAttempts is the number of retries DownloadResults
+// performs per URL when DownloadOptions.RetryAttempts is left at zero.
+const DefaultDownloadRetryAttempts = 3
+
+// DownloadOptions configures DownloadResults.
+type DownloadOptions struct {
+	// Concurrency caps how many URLs are downloaded in parallel.
+	// DefaultDownloadConcurrency if <= 0.
+	Concurrency int
+
+	// ResumePartial resumes a download from the current size of its
+	// "<hash>-<basename>.part" file using an HTTP Range request, instead of
+	// starting over.
+	ResumePartial bool
+
+	// RetryAttempts is how many times a failed download is retried with
+	// exponential backoff before giving up. DefaultDownloadRetryAttempts
+	// if <= 0.
+	RetryAttempts int
+
+	// SHA256Verify maps a URL to its expected hex-encoded SHA-256 digest.
+	// Downloads for URLs not present in the map are not verified.
+	SHA256Verify map[string]string
+
+	// Progress, if set, is called as bytes arrive for each URL. total is
+	// -1 if the server didn't report a size.
+	Progress func(url string, done, total int64)
+}
+
+// DownloadedFile describes one completed download.
+type DownloadedFile struct {
+	URL    string
+	Path   string
+	Bytes  int64
+	SHA256 string
+}
+
+// DownloadResults concurrently downloads urls into dir, streaming each to a
+// "<hash>-<basename>.part" file (see downloadPaths) and atomically renaming
+// it on success. Files are
+// returned in the same order as urls; a download left incomplete by a
+// crash or cancelled context can be resumed later via
+// DownloadOptions.ResumePartial. If any URL ultimately fails (after
+// retries) its slot in the returned slice is the zero DownloadedFile and
+// the error return describes every failure.
+func (c *Client) DownloadResults(ctx context.Context, urls []string, dir string, opts DownloadOptions) ([]DownloadedFile, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create output dir: %w", err)
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultDownloadConcurrency
+	}
+
+	type indexedResult struct {
+		index int
+		file  DownloadedFile
+		err   error
+	}
+
+	sem := make(chan struct{}, concurrency)
+	results := make(chan indexedResult, len(urls))
+	var wg sync.WaitGroup
+
+	for i, u := range urls {
+		wg.Add(1)
+		go func(index int, u string) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			file, err := c.downloadOne(ctx, u, dir, opts)
+			results <- indexedResult{index: index, file: file, err: err}
+		}(i, u)
+	}
+
+	wg.Wait()
+	close(results)
+
+	files := make([]DownloadedFile, len(urls))
+	var failures []string
+	for r := range results {
+		files[r.index] = r.file
+		if r.err != nil {
+			failures = append(failures, r.err.Error())
+		}
+	}
+
+	if len(failures) > 0 {
+		return files, fmt.Errorf("%d of %d downloads failed: %s", len(failures), len(urls), strings.Join(failures, "; "))
+	}
+	return files, nil
+}
+
+// downloadOne downloads a single URL with retry + exponential backoff,
+// resuming from the on-disk .part file when ResumePartial is set.
+func (c *Client) downloadOne(ctx context.Context, rawURL, dir string, opts DownloadOptions) (DownloadedFile, error) {
+	partPath, finalPath, err := downloadPaths(dir, rawURL)
+	if err != nil {
+		return DownloadedFile{}, err
+	}
+
+	retries := opts.RetryAttempts
+	if retries <= 0 {
+		retries = DefaultDownloadRetryAttempts
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			backoff := time.Duration(1<<uint(attempt-1)) * time.Second
+			select {
+			case <-ctx.Done():
+				return DownloadedFile{}, ctx.Err()
+			case <-time.After(backoff):
+			}
+		}
+
+		if err := c.downloadAttempt(ctx, rawURL, partPath, opts); err != nil {
+			lastErr = err
+			continue
+		}
+
+		digest, err := verifyChecksum(partPath, opts.SHA256Verify[rawURL])
+		if err != nil {
+			lastErr = err
+			// The .part file is fully written but corrupt; if left in
+			// place, the next attempt's ResumePartial logic would treat
+			// its size as a valid resume offset and re-request the same
+			// (bad) tail forever. Remove it so the retry starts clean.
+			os.Remove(partPath)
+			continue
+		}
+
+		info, err := os.Stat(partPath)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to stat completed download: %w", err)
+			continue
+		}
+
+		if err := os.Rename(partPath, finalPath); err != nil {
+			lastErr = fmt.Errorf("failed to finalize download: %w", err)
+			continue
+		}
+
+		return DownloadedFile{URL: rawURL, Path: finalPath, Bytes: info.Size(), SHA256: digest}, nil
+	}
+
+	return DownloadedFile{}, fmt.Errorf("download %s: max retries exceeded: %w", rawURL, lastErr)
+}
+
+// downloadAttempt performs a single GET (with a Range header if resuming)
+// and streams the body into partPath, reporting progress as it goes. The
+// .part file is left on disk, complete or not, for a later resume.
+func (c *Client) downloadAttempt(ctx context.Context, rawURL, partPath string, opts DownloadOptions) error {
+	var startOffset int64
+	if opts.ResumePartial {
+		if info, err := os.Stat(partPath); err == nil {
+			startOffset = info.Size()
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if startOffset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", startOffset))
+	}
+
+	resp, err := c.transport.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return &HTTPError{StatusCode: resp.StatusCode, Message: fmt.Sprintf("unexpected status downloading %s", rawURL)}
+	}
+
+	// The server ignored our Range request; restart from scratch.
+	appending := startOffset > 0 && resp.StatusCode == http.StatusPartialContent
+	flags := os.O_CREATE | os.O_WRONLY
+	if appending {
+		flags |= os.O_APPEND
+	} else {
+		flags |= os.O_TRUNC
+		startOffset = 0
+	}
+
+	f, err := os.OpenFile(partPath, flags, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open part file: %w", err)
+	}
+	defer f.Close()
+
+	total := int64(-1)
+	if resp.ContentLength >= 0 {
+		total = startOffset + resp.ContentLength
+	}
+
+	pw := &downloadProgressWriter{w: f, url: rawURL, done: startOffset, total: total, progress: opts.Progress}
+	if _, err := io.Copy(pw, resp.Body); err != nil {
+		return fmt.Errorf("failed to download %s: %w", rawURL, err)
+	}
+	return nil
+}
+
+// downloadProgressWriter wraps an io.Writer, invoking progress after every
+// chunk written.
+type downloadProgressWriter struct {
+	w        io.Writer
+	url      string
+	done     int64
+	total    int64
+	progress func(url string, done, total int64)
+}
+
+func (p *downloadProgressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.done += int64(n)
+	if p.progress != nil {
+		p.progress(p.url, p.done, p.total)
+	}
+	return n, err
+}
+
+// verifyChecksum hashes path and, if expected is non-empty, compares it
+// against the hex-encoded result, returning ErrChecksumMismatch on
+// mismatch. It always returns the digest it computed.
+func verifyChecksum(path, expected string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file for checksum: %w", err)
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return "", fmt.Errorf("failed to hash file: %w", err)
+	}
+	digest := hex.EncodeToString(hasher.Sum(nil))
+
+	if expected != "" && !strings.EqualFold(digest, expected) {
+		return digest, fmt.Errorf("%w: got %s, want %s", ErrChecksumMismatch, digest, expected)
+	}
+	return digest, nil
+}
+
+// downloadPaths derives the "<hash>-<basename>.part" and final on-disk
+// paths for a result URL. The hash prefix is derived from the full URL
+// (not just its basename) so two results that happen to share a basename
+// but come from different hosts or query strings land on distinct files
+// instead of one DownloadResults call's writes colliding with another's.
+func downloadPaths(dir, rawURL string) (partPath, finalPath string, err error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+
+	base := filepath.Base(parsed.Path)
+	if base == "" || base == "." || base == "/" {
+		base = "download"
+	}
+
+	sum := sha256.Sum256([]byte(rawURL))
+	prefix := hex.EncodeToString(sum[:])[:8]
+
+	finalPath = filepath.Join(dir, prefix+"-"+base)
+	partPath = finalPath + ".part"
+	return partPath, finalPath, nil
+}
@@ -0,0 +1,171 @@
+package onethingai
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// EncodingFormat 向量编码格式枚举
+type EncodingFormat string
+
+const (
+	EncodingFormatFloat  EncodingFormat = "float"
+	EncodingFormatBase64 EncodingFormat = "base64"
+)
+
+// EmbeddingsRequest 向量生成请求
+type EmbeddingsRequest struct {
+	Model          string         `json:"model"`
+	Input          []string       `json:"input"`
+	EncodingFormat EncodingFormat `json:"encoding_format,omitempty"`
+	Dimensions     *int           `json:"dimensions,omitempty"`
+	User           string         `json:"user,omitempty"`
+}
+
+// Embedding 单条向量结果
+type Embedding struct {
+	Index     int       `json:"index"`
+	Embedding []float32 `json:"embedding"`
+}
+
+// UnmarshalJSON decodes the embedding field whether the server sent a plain
+// float array (encoding_format=float) or a base64-encoded little-endian
+// float32 buffer (encoding_format=base64), so callers always see []float32.
+func (e *Embedding) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Index     int             `json:"index"`
+		Embedding json.RawMessage `json:"embedding"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	e.Index = raw.Index
+
+	var asFloats []float32
+	if err := json.Unmarshal(raw.Embedding, &asFloats); err == nil {
+		e.Embedding = asFloats
+		return nil
+	}
+
+	var asString string
+	if err := json.Unmarshal(raw.Embedding, &asString); err != nil {
+		return fmt.Errorf("embedding field is neither a float array nor a base64 string")
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(asString)
+	if err != nil {
+		return fmt.Errorf("failed to decode base64 embedding: %w", err)
+	}
+	if len(decoded)%4 != 0 {
+		return fmt.Errorf("invalid base64 embedding length %d", len(decoded))
+	}
+
+	floats := make([]float32, len(decoded)/4)
+	for i := range floats {
+		bits := binary.LittleEndian.Uint32(decoded[i*4:])
+		floats[i] = math.Float32frombits(bits)
+	}
+	e.Embedding = floats
+	return nil
+}
+
+// EmbeddingsResponse 向量生成响应
+type EmbeddingsResponse struct {
+	Object string      `json:"object"`
+	Data   []Embedding `json:"data"`
+	Model  string      `json:"model"`
+	Usage  *Usage      `json:"usage,omitempty"`
+}
+
+// Embeddings generates embeddings for the given inputs
+func (c *Client) Embeddings(ctx context.Context, req *EmbeddingsRequest) (*EmbeddingsResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if err := ValidateModel(req.Model); err != nil {
+		return nil, err
+	}
+	if len(req.Input) == 0 {
+		return nil, NewValidationError("input", "input must contain at least one string")
+	}
+
+	if c.config.Router != nil {
+		if backend, err := c.config.Router.Resolve(req.Model, "embeddings"); err == nil {
+			return backend.Embeddings(ctx, req)
+		}
+	}
+
+	var resp EmbeddingsResponse
+	if err := c.doRequest(ctx, "POST", "/embeddings", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// EmbedTexts embeds texts in batches of c.config.EmbeddingBatchSize
+// (DefaultEmbeddingBatchSize if unset), fanning the batches out across
+// concurrent Embeddings calls, and returns the vectors in the same order
+// as texts.
+func (c *Client) EmbedTexts(ctx context.Context, model string, texts []string) ([][]float32, error) {
+	batchSize := c.config.EmbeddingBatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultEmbeddingBatchSize
+	}
+
+	type batchResult struct {
+		offset int
+		vecs   [][]float32
+		err    error
+	}
+
+	var offsets []int
+	var batches [][]string
+	for i := 0; i < len(texts); i += batchSize {
+		end := i + batchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+		offsets = append(offsets, i)
+		batches = append(batches, texts[i:end])
+	}
+
+	results := make(chan batchResult, len(batches))
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		wg.Add(1)
+		go func(offset int, input []string) {
+			defer wg.Done()
+
+			resp, err := c.Embeddings(ctx, &EmbeddingsRequest{Model: model, Input: input})
+			if err != nil {
+				results <- batchResult{offset: offset, err: err}
+				return
+			}
+
+			vecs := make([][]float32, len(input))
+			for _, e := range resp.Data {
+				if e.Index >= 0 && e.Index < len(vecs) {
+					vecs[e.Index] = e.Embedding
+				}
+			}
+			results <- batchResult{offset: offset, vecs: vecs}
+		}(offsets[i], batch)
+	}
+
+	wg.Wait()
+	close(results)
+
+	out := make([][]float32, len(texts))
+	for r := range results {
+		if r.err != nil {
+			return nil, r.err
+		}
+		copy(out[r.offset:r.offset+len(r.vecs)], r.vecs)
+	}
+	return out, nil
+}
@@ -3,6 +3,7 @@ package onethingai
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // Common errors
@@ -30,6 +31,15 @@ var (
 
 	// ErrCancelled is returned when a request is cancelled
 	ErrCancelled = errors.New("request cancelled")
+
+	// ErrChecksumMismatch is returned when a downloaded file's SHA-256
+	// digest doesn't match the expected value
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+
+	// ErrCircuitOpen is returned by DoRequest when a configured circuit
+	// breaker is open and is short-circuiting requests without touching
+	// the network; see WithCircuitBreaker.
+	ErrCircuitOpen = errors.New("circuit breaker open")
 )
 
 // HTTPError represents an API error response
@@ -37,6 +47,11 @@ type HTTPError struct {
 	StatusCode int
 	Message    string
 	Body       string
+
+	// RetryAfter is the duration parsed from a 429 response's Retry-After
+	// header (delta-seconds or HTTP-date form), or 0 if the response was
+	// not a 429 or didn't include one.
+	RetryAfter time.Duration
 }
 
 func (e *HTTPError) Error() string {
@@ -87,8 +87,8 @@ func example1TextToVideo(ctx context.Context, client *onethingai.Client) error {
 		MaxAttempts: 100,
 		Interval:    5 * time.Second,
 		Timeout:     0,
-		OnProgress: func(progress float64, status onethingai.Status) {
-			log.Printf("progress:%.2f,status:%v", progress, status)
+		OnProgress: func(progress float64, status onethingai.Status, eta time.Duration) {
+			log.Printf("progress:%.2f,status:%v,eta:%v", progress, status, eta)
 		},
 	})
 	if err != nil {
@@ -149,8 +149,8 @@ func example2ImageToVideo(ctx context.Context, client *onethingai.Client) error
 		MaxAttempts: 100,
 		Interval:    5 * time.Second,
 		Timeout:     0,
-		OnProgress: func(progress float64, status onethingai.Status) {
-			log.Printf("progress:%.2f,status:%v", progress, status)
+		OnProgress: func(progress float64, status onethingai.Status, eta time.Duration) {
+			log.Printf("progress:%.2f,status:%v,eta:%v", progress, status, eta)
 		},
 	})
 	if err != nil {
@@ -1,8 +1,21 @@
 package onethingai
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 )
 
 // validateImageRequest validates and prepares image request
@@ -60,7 +73,14 @@ func (c *Client) GenerateImage(ctx context.Context, req interface{}) (*ImageResp
 	if err != nil {
 		return nil, err
 	}
-	return NewImageResponse(reqMap)
+	resp, err := NewImageResponse(reqMap)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.moderateImageResponse(ctx, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
 // GenerateImageStream generates an image with streaming
@@ -81,7 +101,177 @@ func (c *Client) GenerateImageStream(ctx context.Context, req interface{}) (*Str
 	return NewStreamReader[ImageResult](ctx, resp), nil
 }
 
-// WaitForImage polls async job until completion
+// GenerateImageWithFiles is GenerateImage for requests carrying
+// req.Attachments: it streams them as multipart/form-data instead of
+// requiring callers to base64-encode reference images (img2img,
+// inpainting, ControlNet) into the JSON body, via
+// Transport.DoMultipartRequest. The rest of req travels alongside the
+// files as a single JSON-encoded "data" field. If Attachments is empty
+// this is exactly GenerateImage.
+func (c *Client) GenerateImageWithFiles(ctx context.Context, req *ImageRequest) (*ImageResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if len(req.Attachments) == 0 {
+		return c.GenerateImage(ctx, req)
+	}
+
+	if err := validateImageRequest(req, SyncModeSync, nil); err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	resp, err := c.transport.DoMultipartRequest(ctx, "POST", "/generation", url.Values{"data": {string(payload)}}, req.Attachments)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var respMap map[string]interface{}
+	if err := json.Unmarshal(respBody, &respMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	imgResp, err := NewImageResponse(respMap)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.moderateImageResponse(ctx, imgResp); err != nil {
+		return nil, err
+	}
+	return imgResp, nil
+}
+
+// SubmitImageJob submits an async image generation job and returns
+// immediately with the job's initial status; poll it with WaitForImage.
+func (c *Client) SubmitImageJob(ctx context.Context, req interface{}) (*ImageResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	if err := validateImageRequest(req, SyncModeAsync, nil); err != nil {
+		return nil, err
+	}
+
+	var respMap map[string]interface{}
+	err := c.doRequest(ctx, "POST", "/generation", req, &respMap)
+	if err != nil {
+		return nil, err
+	}
+	return NewImageResponse(respMap)
+}
+
+// WaitForImage polls async job until completion, same PollingOptions/
+// OnProgress pattern used for video jobs.
 func (c *Client) WaitForImage(ctx context.Context, jobID string, opts *PollingOptions) (*ImageResponse, error) {
-	return c.PollImageJobStatus(ctx, jobID, opts)
+	resp, err := c.PollImageJobStatus(ctx, jobID, opts)
+	if err != nil {
+		return resp, err
+	}
+	if err := c.moderateImageResponse(ctx, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Decode fetches (if URL-based) or decodes (if base64-embedded) the image
+// result into an image.Image.
+func (r *ImageResult) Decode(ctx context.Context) (image.Image, error) {
+	if r.B64JSON != nil {
+		data, err := decodeDataURLOrB64(*r.B64JSON)
+		if err != nil {
+			return nil, err
+		}
+		img, _, err := image.Decode(bytes.NewReader(data))
+		return img, err
+	}
+
+	if r.URL != nil {
+		req, err := http.NewRequestWithContext(ctx, "GET", *r.URL, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch image: %w", err)
+		}
+		defer resp.Body.Close()
+
+		img, _, err := image.Decode(resp.Body)
+		return img, err
+	}
+
+	return nil, fmt.Errorf("image result has neither url nor b64_json")
+}
+
+// decodeDataURLOrB64 strips a "data:...;base64," prefix if present and
+// base64-decodes the remainder.
+func decodeDataURLOrB64(s string) ([]byte, error) {
+	if idx := strings.Index(s, "base64,"); idx != -1 {
+		s = s[idx+len("base64,"):]
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
+
+// SaveAllImages downloads URL-based results and writes base64-embedded
+// results to disk under dir, returning the written file paths in the same
+// order as results.
+func SaveAllImages(ctx context.Context, results []ImageResult, dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create output dir: %w", err)
+	}
+
+	paths := make([]string, len(results))
+	for i, r := range results {
+		ext := ".png"
+		var data []byte
+
+		switch {
+		case r.B64JSON != nil:
+			raw, err := decodeDataURLOrB64(*r.B64JSON)
+			if err != nil {
+				return nil, fmt.Errorf("image %d: %w", i, err)
+			}
+			data = raw
+
+		case r.URL != nil:
+			req, err := http.NewRequestWithContext(ctx, "GET", *r.URL, nil)
+			if err != nil {
+				return nil, fmt.Errorf("image %d: %w", i, err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return nil, fmt.Errorf("image %d: failed to download: %w", i, err)
+			}
+			raw, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, fmt.Errorf("image %d: %w", i, err)
+			}
+			data = raw
+			if idx := strings.LastIndex(*r.URL, "."); idx != -1 && idx > strings.LastIndex(*r.URL, "/") {
+				ext = (*r.URL)[idx:]
+			}
+
+		default:
+			return nil, fmt.Errorf("image %d has neither url nor b64_json", i)
+		}
+
+		path := filepath.Join(dir, fmt.Sprintf("image_%d%s", i, ext))
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return nil, fmt.Errorf("image %d: failed to write file: %w", i, err)
+		}
+		paths[i] = path
+	}
+
+	return paths, nil
 }
@@ -0,0 +1,240 @@
+package onethingai
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Logger receives one structured event per request and one per response (or
+// final failure). It replaces the old fmt.Sprintf("%+v", ...)-based
+// DebugRequest/DebugResponse, which had no redaction and would happily dump
+// an API key or a multi-megabyte base64 image into a log line.
+//
+// Headers are never passed to Logger, so Authorization/api_key can't leak
+// through it; use WithRequestDump for full local-debug body capture instead.
+type Logger interface {
+	LogRequest(ctx context.Context, attrs ...any)
+	LogResponse(ctx context.Context, attrs ...any)
+}
+
+// noopLogger is the default Logger when WithLogger isn't used.
+type noopLogger struct{}
+
+func (noopLogger) LogRequest(ctx context.Context, attrs ...any)  {}
+func (noopLogger) LogResponse(ctx context.Context, attrs ...any) {}
+
+// slogLogger adapts a *slog.Logger to Logger.
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (s *slogLogger) LogRequest(ctx context.Context, attrs ...any) {
+	s.logger.InfoContext(ctx, "onethingai request", attrs...)
+}
+
+func (s *slogLogger) LogResponse(ctx context.Context, attrs ...any) {
+	s.logger.InfoContext(ctx, "onethingai response", attrs...)
+}
+
+// RequestLog describes a single outgoing HTTP attempt, passed to
+// RequestHookFunc before it's sent. Headers is always the redacted form
+// (see redactHeaders), so hooks can log it directly without leaking the
+// API key.
+type RequestLog struct {
+	Method  string
+	URL     string
+	Headers http.Header
+	Body    []byte
+	Attempt int
+}
+
+// ResponseLog describes the outcome of a RequestLog, passed to
+// ResponseHookFunc once the attempt completes, successfully or not. Err is
+// nil on success; StatusCode/Headers/Body are zero when the attempt never
+// got a response (e.g. a network error).
+type ResponseLog struct {
+	Request    RequestLog
+	StatusCode int
+	Headers    http.Header
+	Body       []byte
+	Duration   time.Duration
+	Err        error
+}
+
+// RequestHookFunc is called once per HTTP attempt, right before it's sent;
+// see WithRequestHook.
+type RequestHookFunc func(ctx context.Context, log *RequestLog)
+
+// ResponseHookFunc is called once per HTTP attempt, right after it
+// completes; see WithResponseHook.
+type ResponseHookFunc func(ctx context.Context, log *ResponseLog)
+
+// RetryHookFunc is called before DoRequest sleeps and retries a failed
+// attempt, with the delay it's about to wait and the error that triggered
+// the retry; see WithRetryHook.
+type RetryHookFunc func(ctx context.Context, attempt int, delay time.Duration, err error)
+
+// ErrorHookFunc is called once DoRequest gives up on a request, with the
+// final error; see WithErrorHook.
+type ErrorHookFunc func(ctx context.Context, err error)
+
+// redactHeaders returns a copy of h with Authorization replaced by a fixed
+// placeholder, safe to hand to a RequestHookFunc/ResponseHookFunc or log.
+func redactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	if out.Get("Authorization") != "" {
+		out.Set("Authorization", "<redacted>")
+	}
+	return out
+}
+
+// DebugLogger renders RequestLog/ResponseLog hook events as human-readable
+// lines to an io.Writer, for quick local troubleshooting without wiring up
+// a real structured logger; see NewDebugLogger.
+type DebugLogger struct {
+	w io.Writer
+}
+
+// NewDebugLogger returns a DebugLogger writing to w. Wire it up with
+// WithDebugLogger, which registers its methods as all four
+// request/response/retry/error hooks at once.
+func NewDebugLogger(w io.Writer) *DebugLogger {
+	return &DebugLogger{w: w}
+}
+
+// OnRequest implements RequestHookFunc.
+func (d *DebugLogger) OnRequest(_ context.Context, log *RequestLog) {
+	fmt.Fprintf(d.w, "--> [attempt %d] %s %s\n%s\n\n", log.Attempt, log.Method, log.URL, log.Body)
+}
+
+// OnResponse implements ResponseHookFunc.
+func (d *DebugLogger) OnResponse(_ context.Context, log *ResponseLog) {
+	if log.Err != nil {
+		fmt.Fprintf(d.w, "<-- [attempt %d] %s %s error=%v (%s)\n\n", log.Request.Attempt, log.Request.Method, log.Request.URL, log.Err, log.Duration)
+		return
+	}
+	fmt.Fprintf(d.w, "<-- [attempt %d] %s %s %d (%s)\n%s\n\n", log.Request.Attempt, log.Request.Method, log.Request.URL, log.StatusCode, log.Duration, log.Body)
+}
+
+// OnRetry implements RetryHookFunc.
+func (d *DebugLogger) OnRetry(_ context.Context, attempt int, delay time.Duration, err error) {
+	fmt.Fprintf(d.w, "... retrying attempt %d after %s: %v\n\n", attempt, delay, err)
+}
+
+// OnError implements ErrorHookFunc.
+func (d *DebugLogger) OnError(_ context.Context, err error) {
+	fmt.Fprintf(d.w, "xxx giving up: %v\n\n", err)
+}
+
+// newRequestID returns a short random hex string correlating a request's
+// LogRequest event with its LogResponse event.
+func newRequestID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
+
+// requestLogFields pulls the model/job_type out of a request body for
+// logging, without caring whether the caller used the map[string]interface{}
+// shape or one of the typed request structs.
+func requestLogFields(body interface{}) (model, jobType string) {
+	switch r := body.(type) {
+	case map[string]interface{}:
+		model, _ = r["model"].(string)
+		if jt, ok := r["job_type"]; ok {
+			jobType = fmt.Sprintf("%v", jt)
+		}
+	case *ImageRequest:
+		model = r.Model
+		jobType = string(r.JobType)
+	case *VideoRequest:
+		model = r.Model
+		jobType = string(r.JobType)
+	case *EmbeddingsRequest:
+		model = r.Model
+		jobType = "embeddings"
+	}
+	return model, jobType
+}
+
+// DebugRequest returns a redacted, human-readable summary of req and logs it
+// through slog.Default() at debug level. Kept for backward compatibility
+// with the old fmt.Sprintf-based helper; new code should rely on the
+// structured events from WithLogger instead.
+func DebugRequest(req interface{}) string {
+	summary := redactedJSON(req)
+	slog.Default().Debug("onethingai request (debug)", "body", summary)
+	return summary
+}
+
+// DebugResponse returns a redacted, human-readable summary of resp and logs
+// it through slog.Default() at debug level. See DebugRequest.
+func DebugResponse(resp interface{}) string {
+	summary := redactedJSON(resp)
+	slog.Default().Debug("onethingai response (debug)", "body", summary)
+	return summary
+}
+
+// redactedJSON marshals v to JSON, truncates any b64_json-shaped field to
+// its byte length, and redacts any authorization/api_key-shaped field,
+// falling back to fmt.Sprintf("%+v", v) if v isn't JSON-marshalable.
+func redactedJSON(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%+v", v)
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return string(raw)
+	}
+
+	out, err := json.Marshal(redactValue(generic))
+	if err != nil {
+		return string(raw)
+	}
+	return string(out)
+}
+
+// redactValue recursively walks JSON-decoded data, truncating b64_json-like
+// fields to their length and redacting authorization/api_key-like fields.
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, vv := range val {
+			switch strings.ToLower(k) {
+			case "b64_json", "b64json":
+				if s, ok := vv.(string); ok {
+					out[k] = fmt.Sprintf("<%d bytes>", len(s))
+					continue
+				}
+			case "authorization", "api_key", "apikey":
+				out[k] = "<redacted>"
+				continue
+			}
+			out[k] = redactValue(vv)
+		}
+		return out
+
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, vv := range val {
+			out[i] = redactValue(vv)
+		}
+		return out
+
+	default:
+		return val
+	}
+}
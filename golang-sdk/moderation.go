@@ -0,0 +1,414 @@
+package onethingai
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ModerationPolicy decides what Client does with a Moderator's verdict once
+// a generation completes.
+type ModerationPolicy string
+
+const (
+	// ModerateBeforeReturn drops failed results before they're returned to
+	// the caller.
+	ModerateBeforeReturn ModerationPolicy = "before_return"
+
+	// ModerateAsync attaches the verdict to each result's Metadata under
+	// the "moderation" key instead of filtering anything out, leaving the
+	// decision to the caller.
+	ModerateAsync ModerationPolicy = "async"
+)
+
+// DefaultFrameSampleCount is how many evenly-spaced frames SampleFrameTimestamps
+// returns when a Moderator doesn't override it.
+const DefaultFrameSampleCount = 5
+
+// FrameVerdict is a per-frame moderation result for a sampled point in a
+// video.
+type FrameVerdict struct {
+	Timestamp  float64            `json:"timestamp"` // seconds into the video
+	Passed     bool               `json:"passed"`
+	Categories []string           `json:"categories,omitempty"`
+	Scores     map[string]float64 `json:"scores,omitempty"`
+}
+
+// ModerationVerdict is a Moderator's decision for one generated result.
+type ModerationVerdict struct {
+	Passed         bool               `json:"passed"`
+	Categories     []string           `json:"categories,omitempty"`
+	Scores         map[string]float64 `json:"scores,omitempty"`
+	FrameSummaries []FrameVerdict     `json:"frame_summaries,omitempty"`
+}
+
+// Moderator reviews generated images and videos for policy violations.
+type Moderator interface {
+	ModerateImage(ctx context.Context, result *ImageResult) (*ModerationVerdict, error)
+	ModerateVideo(ctx context.Context, result *VideoResult) (*ModerationVerdict, error)
+}
+
+// WithModerator wires m into the client, reviewing every image/video
+// result GenerateImage, GenerateVideo, WaitForImage, and WaitForVideo
+// return, and applying policy to the verdict.
+func WithModerator(m Moderator, policy ModerationPolicy) ClientOption {
+	return func(c *Config) {
+		c.Moderator = m
+		c.ModerationPolicy = policy
+	}
+}
+
+// SampleFrameTimestamps returns n evenly-spaced timestamps (in seconds)
+// across [0, durationSeconds), for a Moderator that reviews videos by
+// sampling frames rather than scanning the whole stream.
+// DefaultFrameSampleCount is used if n <= 0. A non-positive
+// durationSeconds yields the single timestamp 0.
+func SampleFrameTimestamps(durationSeconds float64, n int) []float64 {
+	if n <= 0 {
+		n = DefaultFrameSampleCount
+	}
+	if durationSeconds <= 0 || n == 1 {
+		return []float64{0}
+	}
+
+	step := durationSeconds / float64(n)
+	timestamps := make([]float64, n)
+	for i := 0; i < n; i++ {
+		timestamps[i] = step * float64(i)
+	}
+	return timestamps
+}
+
+// NoopModerator passes every result, useful as a default or in tests that
+// need a Moderator without pulling in a real provider.
+type NoopModerator struct{}
+
+func (NoopModerator) ModerateImage(ctx context.Context, result *ImageResult) (*ModerationVerdict, error) {
+	return &ModerationVerdict{Passed: true}, nil
+}
+
+func (NoopModerator) ModerateVideo(ctx context.Context, result *VideoResult) (*ModerationVerdict, error) {
+	return &ModerationVerdict{Passed: true}, nil
+}
+
+// moderateImageResults runs c.config.Moderator over results and applies
+// c.config.ModerationPolicy, returning the results the caller should see.
+// A nil Moderator is a no-op.
+func (c *Client) moderateImageResults(ctx context.Context, results []ImageResult) ([]ImageResult, error) {
+	if c.config.Moderator == nil {
+		return results, nil
+	}
+
+	kept := make([]ImageResult, 0, len(results))
+	for _, result := range results {
+		verdict, err := c.config.Moderator.ModerateImage(ctx, &result)
+		if err != nil {
+			return nil, fmt.Errorf("moderation failed: %w", err)
+		}
+
+		if c.config.ModerationPolicy == ModerateAsync {
+			result.Metadata = withModerationMetadata(result.Metadata, verdict)
+			kept = append(kept, result)
+			continue
+		}
+
+		if verdict.Passed {
+			kept = append(kept, result)
+		}
+	}
+	return kept, nil
+}
+
+// moderateVideoResults is moderateImageResults for VideoResult.
+func (c *Client) moderateVideoResults(ctx context.Context, results []VideoResult) ([]VideoResult, error) {
+	if c.config.Moderator == nil {
+		return results, nil
+	}
+
+	kept := make([]VideoResult, 0, len(results))
+	for _, result := range results {
+		verdict, err := c.config.Moderator.ModerateVideo(ctx, &result)
+		if err != nil {
+			return nil, fmt.Errorf("moderation failed: %w", err)
+		}
+
+		if c.config.ModerationPolicy == ModerateAsync {
+			result.Metadata = withModerationMetadata(result.Metadata, verdict)
+			kept = append(kept, result)
+			continue
+		}
+
+		if verdict.Passed {
+			kept = append(kept, result)
+		}
+	}
+	return kept, nil
+}
+
+func withModerationMetadata(metadata map[string]interface{}, verdict *ModerationVerdict) map[string]interface{} {
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	metadata["moderation"] = verdict
+	return metadata
+}
+
+// moderateImageResponse moderates every result in resp in place.
+func (c *Client) moderateImageResponse(ctx context.Context, resp *ImageResponse) error {
+	if c.config.Moderator == nil || resp == nil || resp.Data.Result == nil {
+		return nil
+	}
+	filtered, err := c.moderateImageResults(ctx, resp.Data.Result.Data)
+	if err != nil {
+		return err
+	}
+	resp.Data.Result.Data = filtered
+	return nil
+}
+
+// moderateVideoResponse moderates every result in resp in place.
+func (c *Client) moderateVideoResponse(ctx context.Context, resp *VideoResponse) error {
+	if c.config.Moderator == nil || resp == nil || resp.Data.Result == nil {
+		return nil
+	}
+	filtered, err := c.moderateVideoResults(ctx, resp.Data.Result.Data)
+	if err != nil {
+		return err
+	}
+	resp.Data.Result.Data = filtered
+	return nil
+}
+
+// ==================== Alibaba Green reference Moderator ====================
+
+// DefaultAlibabaGreenEndpoint is the Alibaba Cloud Green Network API host
+// AlibabaGreenModerator targets when Endpoint is unset.
+const DefaultAlibabaGreenEndpoint = "https://green.cn-shanghai.aliyuncs.com"
+
+// AlibabaGreenModerator is a reference Moderator backed by Alibaba Cloud
+// Green Network's image/video synchronous scan API. It signs requests with
+// the standard Aliyun RPC HMAC-SHA1 scheme and maps the provider's
+// "suggestion"/"label"/"rate" fields onto ModerationVerdict.
+type AlibabaGreenModerator struct {
+	AccessKeyID     string
+	AccessKeySecret string
+
+	// Endpoint overrides DefaultAlibabaGreenEndpoint.
+	Endpoint string
+
+	// HTTPClient overrides http.DefaultClient.
+	HTTPClient *http.Client
+
+	// FrameSampleCount overrides DefaultFrameSampleCount for video review.
+	FrameSampleCount int
+}
+
+type alibabaGreenScanResult struct {
+	Suggestion string  `json:"suggestion"`
+	Label      string  `json:"label"`
+	Rate       float64 `json:"rate"`
+}
+
+func (m *AlibabaGreenModerator) endpoint() string {
+	if m.Endpoint != "" {
+		return m.Endpoint
+	}
+	return DefaultAlibabaGreenEndpoint
+}
+
+func (m *AlibabaGreenModerator) httpClient() *http.Client {
+	if m.HTTPClient != nil {
+		return m.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// ModerateImage submits the image's URL (fetching a data URL result isn't
+// supported by the sync scan API, which requires a reachable URL) for
+// review.
+func (m *AlibabaGreenModerator) ModerateImage(ctx context.Context, result *ImageResult) (*ModerationVerdict, error) {
+	if result.URL == nil {
+		return nil, fmt.Errorf("alibaba green moderator: image result has no URL")
+	}
+
+	scan, err := m.scan(ctx, "imageSyncScan", *result.URL)
+	if err != nil {
+		return nil, err
+	}
+	return scanResultToVerdict(scan), nil
+}
+
+// ModerateVideo samples FrameSampleCount (DefaultFrameSampleCount if unset)
+// evenly-spaced timestamps across the video's duration, asking the Green
+// API to seek to each one before scanning, and aggregates the per-frame
+// verdicts into a single one.
+func (m *AlibabaGreenModerator) ModerateVideo(ctx context.Context, result *VideoResult) (*ModerationVerdict, error) {
+	if result.URL == nil {
+		return nil, fmt.Errorf("alibaba green moderator: video result has no URL")
+	}
+
+	duration := float64(result.GetDuration())
+	timestamps := SampleFrameTimestamps(duration, m.FrameSampleCount)
+
+	frames := make([]FrameVerdict, 0, len(timestamps))
+	overallPassed := true
+	categorySet := make(map[string]struct{})
+
+	for _, ts := range timestamps {
+		scan, err := m.scanFrame(ctx, "videoSyncScan", *result.URL, ts)
+		if err != nil {
+			return nil, err
+		}
+		verdict := scanResultToVerdict(scan)
+		frames = append(frames, FrameVerdict{
+			Timestamp:  ts,
+			Passed:     verdict.Passed,
+			Categories: verdict.Categories,
+			Scores:     verdict.Scores,
+		})
+		if !verdict.Passed {
+			overallPassed = false
+		}
+		for _, cat := range verdict.Categories {
+			categorySet[cat] = struct{}{}
+		}
+	}
+
+	categories := make([]string, 0, len(categorySet))
+	for cat := range categorySet {
+		categories = append(categories, cat)
+	}
+	sort.Strings(categories)
+
+	return &ModerationVerdict{
+		Passed:         overallPassed,
+		Categories:     categories,
+		FrameSummaries: frames,
+	}, nil
+}
+
+// scan performs a single Aliyun Green Network sync-scan RPC call for the
+// given action ("imageSyncScan" or "videoSyncScan") against targetURL.
+func (m *AlibabaGreenModerator) scan(ctx context.Context, action, targetURL string) (*alibabaGreenScanResult, error) {
+	return m.doScan(ctx, action, targetURL, nil)
+}
+
+// scanFrame is scan for videoSyncScan's per-frame review: it asks the Green
+// API to seek to offsetSeconds into targetURL before scanning, via the
+// "Seek" param the video scan action accepts, so each sampled timestamp
+// reviews a different frame instead of the whole video repeatedly.
+func (m *AlibabaGreenModerator) scanFrame(ctx context.Context, action, targetURL string, offsetSeconds float64) (*alibabaGreenScanResult, error) {
+	return m.doScan(ctx, action, targetURL, &offsetSeconds)
+}
+
+func (m *AlibabaGreenModerator) doScan(ctx context.Context, action, targetURL string, offsetSeconds *float64) (*alibabaGreenScanResult, error) {
+	params := url.Values{}
+	params.Set("Action", action)
+	params.Set("Version", "2018-05-09")
+	params.Set("Format", "JSON")
+	params.Set("AccessKeyId", m.AccessKeyID)
+	params.Set("SignatureMethod", "HMAC-SHA1")
+	params.Set("SignatureVersion", "1.0")
+	params.Set("Url", targetURL)
+	if offsetSeconds != nil {
+		params.Set("Seek", strconv.FormatFloat(*offsetSeconds, 'f', -1, 64))
+	}
+
+	signed := signAliyunRequest("POST", params, m.AccessKeySecret)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", m.endpoint()+"/", bytes.NewBufferString(signed.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("alibaba green moderator: failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := m.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("alibaba green moderator: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Message: "alibaba green moderator: scan request failed"}
+	}
+
+	var result alibabaGreenScanResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("alibaba green moderator: failed to decode response: %w", err)
+	}
+	return &result, nil
+}
+
+func scanResultToVerdict(scan *alibabaGreenScanResult) *ModerationVerdict {
+	passed := scan.Suggestion == "pass"
+
+	var categories []string
+	if scan.Label != "" && scan.Label != "normal" {
+		categories = []string{scan.Label}
+	}
+
+	scores := map[string]float64{}
+	if scan.Label != "" {
+		scores[scan.Label] = scan.Rate
+	}
+
+	return &ModerationVerdict{Passed: passed, Categories: categories, Scores: scores}
+}
+
+// signAliyunRequest adds Timestamp/SignatureNonce/Signature to params per
+// Aliyun's RPC request signing algorithm (HMAC-SHA1 over
+// "POST&<percent-encoded '/'>&<percent-encoded sorted query string>").
+func signAliyunRequest(method string, params url.Values, secret string) url.Values {
+	params.Set("Timestamp", aliyunTimestamp())
+	params.Set("SignatureNonce", aliyunNonce())
+
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	canonical := url.Values{}
+	for _, k := range keys {
+		canonical.Set(k, params.Get(k))
+	}
+	queryString := aliyunPercentEncode(canonical.Encode())
+
+	stringToSign := method + "&" + aliyunPercentEncode("/") + "&" + queryString
+
+	mac := hmac.New(sha1.New, []byte(secret+"&"))
+	mac.Write([]byte(stringToSign))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	params.Set("Signature", signature)
+	return params
+}
+
+// aliyunPercentEncode applies the RFC 3986-compliant percent-encoding
+// Aliyun's signing algorithm requires, which differs from Go's
+// url.QueryEscape for a few characters.
+func aliyunPercentEncode(s string) string {
+	encoded := url.QueryEscape(s)
+	encoded = strings.ReplaceAll(encoded, "+", "%20")
+	encoded = strings.ReplaceAll(encoded, "*", "%2A")
+	encoded = strings.ReplaceAll(encoded, "%7E", "~")
+	return encoded
+}
+
+func aliyunTimestamp() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05Z")
+}
+
+func aliyunNonce() string {
+	return strconv.FormatInt(time.Now().UTC().UnixNano(), 36)
+}
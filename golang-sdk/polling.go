@@ -3,22 +3,81 @@ package onethingai
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"time"
 )
 
+// pollProgressEpsilon is the minimum per-poll progress delta the adaptive
+// strategies treat as "still moving"; anything smaller falls back to the
+// previous interval instead of dividing by a near-zero rate.
+const pollProgressEpsilon = 1e-6
+
+// PollingStrategy selects how pollJobStatus paces itself between polls.
+type PollingStrategy string
+
+const (
+	// PollingStrategyFixedInterval polls every Interval, the historical
+	// behavior and the default.
+	PollingStrategyFixedInterval PollingStrategy = "fixed_interval"
+
+	// PollingStrategyExponentialBackoff multiplies the interval by
+	// Multiplier after each poll, clamped to [MinInterval, MaxInterval].
+	PollingStrategyExponentialBackoff PollingStrategy = "exponential_backoff"
+
+	// PollingStrategyAdaptiveFromProgress extrapolates the job's
+	// completion rate from how much Progress moved since the last poll
+	// and sleeps accordingly, clamped to [MinInterval, MaxInterval].
+	PollingStrategyAdaptiveFromProgress PollingStrategy = "adaptive_from_progress"
+)
+
+// jitterRand backs applyJitter; package-level like WeightedBackend's rng,
+// but shared rather than per-instance since jitter carries no state worth
+// isolating.
+var jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+
 // PollingOptions configure async job polling behavior
 type PollingOptions struct {
 	// MaxAttempts is the maximum number of polling attempts (0 = unlimited)
 	MaxAttempts int
 
-	// Interval is the time between polling attempts
+	// Interval is the time between polling attempts under
+	// PollingStrategyFixedInterval, and the starting interval for
+	// PollingStrategyExponentialBackoff and
+	// PollingStrategyAdaptiveFromProgress. For both of those it also stands
+	// in for MinInterval when MinInterval is zero.
 	Interval time.Duration
 
 	// Timeout is the maximum time to wait for job completion
 	Timeout time.Duration
 
-	// OnProgress is called on each polling iteration with progress update
-	OnProgress func(progress float64, status Status)
+	// OnProgress is called on each polling iteration with a progress
+	// update and an estimated time remaining, extrapolated linearly from
+	// how much progress moved since the previous poll.
+	OnProgress func(progress float64, status Status, eta time.Duration)
+
+	// MaxConcurrency caps how many GetJobStatus calls PollBatch issues at
+	// once. Zero means one call per job in the batch (no cap).
+	MaxConcurrency int
+
+	// Strategy selects the pacing algorithm between polls. Zero value is
+	// PollingStrategyFixedInterval.
+	Strategy PollingStrategy
+
+	// MinInterval and MaxInterval clamp the computed interval for
+	// PollingStrategyExponentialBackoff and PollingStrategyAdaptiveFromProgress.
+	// Zero means "use Interval" for MinInterval and "use MinInterval" for
+	// MaxInterval.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+
+	// Multiplier scales the interval on each tick under
+	// PollingStrategyExponentialBackoff. Zero or negative means 1.5.
+	Multiplier float64
+
+	// Jitter randomizes the computed interval by up to this fraction
+	// (0.0-1.0) in either direction, to avoid synchronized polling
+	// across many concurrently-running jobs.
+	Jitter float64
 }
 
 // DefaultPollingOptions returns default polling options
@@ -28,7 +87,89 @@ func DefaultPollingOptions() *PollingOptions {
 		Interval:    2 * time.Second,
 		Timeout:     5 * time.Minute,
 		OnProgress:  nil,
+		Strategy:    PollingStrategyFixedInterval,
+	}
+}
+
+// estimatePollETA linearly extrapolates the remaining time from how far
+// progress moved over elapsed: remaining = (1 - progress) / rate, where
+// rate = progressDelta / elapsed. Returns 0 when the rate can't be
+// estimated yet (first poll, or no measurable progress).
+func estimatePollETA(progress, lastProgress float64, elapsed time.Duration) time.Duration {
+	delta := progress - lastProgress
+	if delta <= pollProgressEpsilon || elapsed <= 0 {
+		return 0
+	}
+	remaining := (1 - progress) / delta * float64(elapsed)
+	if remaining < 0 {
+		return 0
+	}
+	return time.Duration(remaining)
+}
+
+// applyJitter randomizes d by up to jitter (0.0-1.0) of its own length in
+// either direction.
+func applyJitter(d time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 {
+		return d
+	}
+	if jitter > 1 {
+		jitter = 1
 	}
+	spread := float64(d) * jitter
+	offset := (jitterRand.Float64()*2 - 1) * spread
+	next := time.Duration(float64(d) + offset)
+	if next < 0 {
+		return 0
+	}
+	return next
+}
+
+// nextPollInterval computes the sleep before the next poll per
+// opts.Strategy, clamping exponential backoff and adaptive intervals to
+// [MinInterval, MaxInterval].
+func nextPollInterval(opts *PollingOptions, current, base time.Duration, progress, lastProgress float64, elapsed time.Duration) time.Duration {
+	if opts.Strategy == PollingStrategyFixedInterval || opts.Strategy == "" {
+		return base
+	}
+
+	minInterval := opts.MinInterval
+	if minInterval <= 0 {
+		minInterval = base
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = minInterval
+	}
+
+	var next time.Duration
+	switch opts.Strategy {
+	case PollingStrategyExponentialBackoff:
+		multiplier := opts.Multiplier
+		if multiplier <= 0 {
+			multiplier = 1.5
+		}
+		next = time.Duration(float64(current) * multiplier)
+
+	case PollingStrategyAdaptiveFromProgress:
+		delta := progress - lastProgress
+		if delta <= pollProgressEpsilon || elapsed <= 0 {
+			next = current
+		} else {
+			next = time.Duration((1 - progress) / delta * float64(elapsed))
+		}
+
+	default:
+		next = base
+	}
+
+	if next < minInterval {
+		next = minInterval
+	}
+	if next > maxInterval {
+		next = maxInterval
+	}
+	return applyJitter(next, opts.Jitter)
 }
 
 // pollJobStatus is a generic internal polling function for image and video jobs
@@ -49,9 +190,15 @@ func pollJobStatus[T any](
 		defer cancel()
 	}
 
+	baseInterval := opts.Interval
+	if baseInterval <= 0 {
+		baseInterval = DefaultPollingOptions().Interval
+	}
+	interval := baseInterval
+
 	attempt := 0
-	ticker := time.NewTicker(opts.Interval)
-	defer ticker.Stop()
+	lastProgress := 0.0
+	lastPollTime := time.Now()
 
 	for {
 		if opts.MaxAttempts > 0 && attempt >= opts.MaxAttempts {
@@ -61,17 +208,24 @@ func pollJobStatus[T any](
 		resp, err := getStatus(ctx, jobID)
 		if err != nil {
 			// 可选：log.Printf("polling attempt %d failed: %v", attempt+1, err)
+			timer := time.NewTimer(interval)
 			select {
 			case <-ctx.Done():
+				timer.Stop()
 				return nil, ctx.Err()
-			case <-ticker.C:
+			case <-timer.C:
 				attempt++
 				continue
 			}
 		}
 
+		now := time.Now()
+		elapsed := now.Sub(lastPollTime)
+		progress := resp.Data.Progress
+		eta := estimatePollETA(progress, lastProgress, elapsed)
+
 		if opts.OnProgress != nil {
-			opts.OnProgress(resp.Data.Progress, resp.Data.Status)
+			opts.OnProgress(progress, resp.Data.Status, eta)
 		}
 		if resp.Data.IsCompleted() {
 			return resp, nil
@@ -80,10 +234,16 @@ func pollJobStatus[T any](
 			return resp, fmt.Errorf("job failed: %v", resp.Data.Error)
 		}
 
+		interval = nextPollInterval(opts, interval, baseInterval, progress, lastProgress, elapsed)
+		lastProgress = progress
+		lastPollTime = now
+
+		timer := time.NewTimer(interval)
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return nil, ctx.Err()
-		case <-ticker.C:
+		case <-timer.C:
 			attempt++
 		}
 	}
@@ -0,0 +1,124 @@
+package onethingai
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimatePollETA(t *testing.T) {
+	// 25% progress in 10s -> rate 0.025/s, 75% remaining -> 30s.
+	eta := estimatePollETA(0.25, 0.0, 10*time.Second)
+	if eta != 30*time.Second {
+		t.Errorf("estimatePollETA() = %v, want 30s", eta)
+	}
+}
+
+func TestEstimatePollETANoMeasurableProgressReturnsZero(t *testing.T) {
+	if got := estimatePollETA(0.25, 0.25, 10*time.Second); got != 0 {
+		t.Errorf("estimatePollETA() = %v, want 0 when progress hasn't moved", got)
+	}
+	if got := estimatePollETA(0.25, 0.0, 0); got != 0 {
+		t.Errorf("estimatePollETA() = %v, want 0 when elapsed is zero", got)
+	}
+}
+
+func TestEstimatePollETANeverNegative(t *testing.T) {
+	// progress > 1 (a buggy server) shouldn't produce a negative ETA.
+	if got := estimatePollETA(1.5, 0.0, time.Second); got < 0 {
+		t.Errorf("estimatePollETA() = %v, want >= 0", got)
+	}
+}
+
+func TestEstimatedCompletionMatchesEstimatePollETA(t *testing.T) {
+	// Created is stored in whole seconds, so the elapsed EstimatedCompletion
+	// computes internally can exceed the one computed here by up to 1s. The
+	// ETA formula scales that gap by (1-progress)/progress, which is 1 at
+	// Progress 0.5, so the results stay within the 1s tolerance.
+	created := time.Now().Add(-1000 * time.Second)
+	resp := ImageAndVideoDataResponse[ImageResult]{
+		Created:  created.Unix(),
+		Progress: 0.5,
+	}
+
+	got := resp.EstimatedCompletion()
+
+	elapsed := time.Since(created)
+	want := created.Add(elapsed).Add(estimatePollETA(resp.Progress, 0, elapsed))
+	if diff := got.Sub(want); diff < -time.Second || diff > time.Second {
+		t.Errorf("EstimatedCompletion() = %v, want ~%v (diff %v)", got, want, diff)
+	}
+}
+
+func TestEstimatedCompletionZeroProgress(t *testing.T) {
+	resp := ImageAndVideoDataResponse[ImageResult]{
+		Created:  time.Now().Unix(),
+		Progress: 0,
+	}
+	if got := resp.EstimatedCompletion(); !got.IsZero() {
+		t.Errorf("EstimatedCompletion() = %v, want zero time", got)
+	}
+}
+
+func TestApplyJitterZeroIsNoop(t *testing.T) {
+	if got := applyJitter(5*time.Second, 0); got != 5*time.Second {
+		t.Errorf("applyJitter(jitter=0) = %v, want unchanged", got)
+	}
+}
+
+func TestApplyJitterStaysWithinBounds(t *testing.T) {
+	d := 10 * time.Second
+	for i := 0; i < 100; i++ {
+		got := applyJitter(d, 0.2)
+		if got < 0 {
+			t.Fatalf("applyJitter returned negative duration: %v", got)
+		}
+		if got < 7*time.Second || got > 13*time.Second {
+			t.Fatalf("applyJitter(10s, 0.2) = %v, want within [8s, 12s] range (with float slack)", got)
+		}
+	}
+}
+
+func TestApplyJitterClampsAboveOne(t *testing.T) {
+	d := 10 * time.Second
+	for i := 0; i < 50; i++ {
+		if got := applyJitter(d, 5); got < 0 {
+			t.Fatalf("applyJitter with jitter>1 returned negative duration: %v", got)
+		}
+	}
+}
+
+func TestNextPollIntervalFixedIntervalReturnsBase(t *testing.T) {
+	opts := &PollingOptions{Strategy: PollingStrategyFixedInterval}
+	got := nextPollInterval(opts, 5*time.Second, 2*time.Second, 0.5, 0.1, time.Second)
+	if got != 2*time.Second {
+		t.Errorf("nextPollInterval() = %v, want base (2s)", got)
+	}
+}
+
+func TestNextPollIntervalExponentialBackoffClamps(t *testing.T) {
+	opts := &PollingOptions{
+		Strategy:    PollingStrategyExponentialBackoff,
+		Multiplier:  2,
+		MinInterval: time.Second,
+		MaxInterval: 5 * time.Second,
+		Jitter:      0,
+	}
+	got := nextPollInterval(opts, 4*time.Second, time.Second, 0, 0, 0)
+	if got != 5*time.Second {
+		t.Errorf("nextPollInterval() = %v, want clamped to MaxInterval (5s)", got)
+	}
+}
+
+func TestNextPollIntervalAdaptiveFromProgressClampsToMin(t *testing.T) {
+	opts := &PollingOptions{
+		Strategy:    PollingStrategyAdaptiveFromProgress,
+		MinInterval: 2 * time.Second,
+		MaxInterval: time.Minute,
+		Jitter:      0,
+	}
+	// No measurable progress delta -> falls back to current, then clamped up to MinInterval.
+	got := nextPollInterval(opts, time.Second, time.Second, 0.5, 0.5, time.Second)
+	if got != 2*time.Second {
+		t.Errorf("nextPollInterval() = %v, want clamped to MinInterval (2s)", got)
+	}
+}
@@ -106,9 +106,12 @@ func (s *StreamReader[T]) ReadAll() ([]StreamDataResponse[T], error) {
 
 // TextStreamReader reads text streaming responses (e.g., OpenAI-style streaming)
 type TextStreamReader struct {
-	resp   *http.Response
-	reader *bufio.Reader
-	ctx    context.Context
+	resp      *http.Response
+	reader    *bufio.Reader
+	ctx       context.Context
+	usage     *Usage
+	toolCalls map[int]*toolCallBuilder
+	toolOrder []int
 }
 
 // NewTextStreamReader creates a new text stream reader
@@ -120,6 +123,13 @@ func NewTextStreamReader(ctx context.Context, resp *http.Response) *TextStreamRe
 	}
 }
 
+// Usage returns the aggregated token usage for the stream, or nil if the
+// upstream never sent a usage chunk (e.g. stream_options.include_usage was
+// not set on the request) or the stream hasn't finished yet.
+func (s *TextStreamReader) Usage() *Usage {
+	return s.usage
+}
+
 // Next reads the next chunk from the text stream
 func (s *TextStreamReader) Next() (map[string]interface{}, error) {
 	// Check if context is cancelled
@@ -150,6 +160,8 @@ func (s *TextStreamReader) Next() (map[string]interface{}, error) {
 				if err := json.Unmarshal(dataBuffer.Bytes(), &result); err != nil {
 					return nil, fmt.Errorf("failed to parse stream data: %w", err)
 				}
+				s.captureUsage(result)
+				s.captureToolCalls(result)
 				return result, nil
 			}
 			continue
@@ -172,6 +184,32 @@ func (s *TextStreamReader) Next() (map[string]interface{}, error) {
 	}
 }
 
+// captureUsage stashes the final usage-only chunk a server emits when
+// stream_options.include_usage is set: choices is empty/absent and usage
+// is populated.
+func (s *TextStreamReader) captureUsage(chunk map[string]interface{}) {
+	choices, hasChoices := chunk["choices"].([]interface{})
+	if hasChoices && len(choices) > 0 {
+		return
+	}
+
+	usageRaw, ok := chunk["usage"]
+	if !ok || usageRaw == nil {
+		return
+	}
+
+	raw, err := json.Marshal(usageRaw)
+	if err != nil {
+		return
+	}
+
+	var usage Usage
+	if err := json.Unmarshal(raw, &usage); err != nil {
+		return
+	}
+	s.usage = &usage
+}
+
 // Close closes the stream
 func (s *TextStreamReader) Close() error {
 	if s.resp != nil && s.resp.Body != nil {
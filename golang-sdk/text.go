@@ -56,11 +56,30 @@ func validateTextRequest(req map[string]interface{}, jobType TextJobType, stream
 	return nil
 }
 
+// routedBackend resolves a Backend for model/jobType via the client's
+// Router, if WithBackend options configured one. ok is false when no
+// Router is set or no backend matches, meaning the caller should fall
+// through to the client's own HTTP implementation.
+func (c *Client) routedBackend(req map[string]interface{}, jobType string) (Backend, bool) {
+	if c.config.Router == nil {
+		return nil, false
+	}
+	model, _ := req["model"].(string)
+	backend, err := c.config.Router.Resolve(model, jobType)
+	if err != nil {
+		return nil, false
+	}
+	return backend, true
+}
+
 // ChatCompletion performs chat completion
 func (c *Client) ChatCompletion(ctx context.Context, req map[string]interface{}) (*TextResponse, error) {
 	if err := validateTextRequest(req, TextJobTypeChatCompletions, false); err != nil {
 		return nil, err
 	}
+	if backend, ok := c.routedBackend(req, string(TextJobTypeChatCompletions)); ok {
+		return backend.ChatCompletion(ctx, req)
+	}
 	return c.generateText(ctx, req)
 }
 
@@ -69,6 +88,9 @@ func (c *Client) Completions(ctx context.Context, req map[string]interface{}) (*
 	if err := validateTextRequest(req, TextJobTypeCompletions, false); err != nil {
 		return nil, err
 	}
+	if backend, ok := c.routedBackend(req, string(TextJobTypeCompletions)); ok {
+		return backend.Completions(ctx, req)
+	}
 	return c.generateText(ctx, req)
 }
 
@@ -77,6 +99,9 @@ func (c *Client) Responses(ctx context.Context, req map[string]interface{}) (*Te
 	if err := validateTextRequest(req, TextJobTypeResponses, false); err != nil {
 		return nil, err
 	}
+	if backend, ok := c.routedBackend(req, string(TextJobTypeResponses)); ok {
+		return backend.Responses(ctx, req)
+	}
 	return c.generateText(ctx, req)
 }
 
@@ -122,6 +147,9 @@ func (c *Client) ChatCompletionStreaming(ctx context.Context, req map[string]int
 	if err := validateTextRequest(req, TextJobTypeChatCompletions, true); err != nil {
 		return nil, err
 	}
+	if backend, ok := c.routedBackend(req, string(TextJobTypeChatCompletions)); ok {
+		return backend.ChatCompletionStreaming(ctx, req)
+	}
 	return c.generateTextStream(ctx, req)
 }
 
@@ -130,6 +158,9 @@ func (c *Client) CompletionsStreaming(ctx context.Context, req map[string]interf
 	if err := validateTextRequest(req, TextJobTypeCompletions, true); err != nil {
 		return nil, err
 	}
+	if backend, ok := c.routedBackend(req, string(TextJobTypeCompletions)); ok {
+		return backend.CompletionsStreaming(ctx, req)
+	}
 	return c.generateTextStream(ctx, req)
 }
 
@@ -138,6 +169,9 @@ func (c *Client) ResponsesStreaming(ctx context.Context, req map[string]interfac
 	if err := validateTextRequest(req, TextJobTypeResponses, true); err != nil {
 		return nil, err
 	}
+	if backend, ok := c.routedBackend(req, string(TextJobTypeResponses)); ok {
+		return backend.ResponsesStreaming(ctx, req)
+	}
 	return c.generateTextStream(ctx, req)
 }
 
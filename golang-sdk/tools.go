@@ -0,0 +1,224 @@
+package onethingai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ==================== Tool/Function Calling ====================
+
+// FunctionDefinition 工具对应的函数定义
+type FunctionDefinition struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"` // JSON Schema
+}
+
+// Tool 可供模型调用的工具
+type Tool struct {
+	Type     string             `json:"type"` // 目前仅支持 "function"
+	Function FunctionDefinition `json:"function"`
+}
+
+// NewFunctionTool builds a Tool of type "function"
+func NewFunctionTool(name, description string, parameters json.RawMessage) Tool {
+	return Tool{
+		Type: "function",
+		Function: FunctionDefinition{
+			Name:        name,
+			Description: description,
+			Parameters:  parameters,
+		},
+	}
+}
+
+// ToolChoiceMode 控制模型是否/如何调用工具
+type ToolChoiceMode string
+
+const (
+	ToolChoiceAuto     ToolChoiceMode = "auto"
+	ToolChoiceNone     ToolChoiceMode = "none"
+	ToolChoiceRequired ToolChoiceMode = "required"
+)
+
+// NamedToolChoice forces the model to call a specific function
+type NamedToolChoice struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name string `json:"name"`
+	} `json:"function"`
+}
+
+// ForceTool builds a ToolChoice that forces a call to the named function
+func ForceTool(name string) NamedToolChoice {
+	choice := NamedToolChoice{Type: "function"}
+	choice.Function.Name = name
+	return choice
+}
+
+// ToolHandler executes a tool call and returns its result, typically a JSON
+// string, to be fed back to the model as a "tool" role message.
+type ToolHandler func(ctx context.Context, arguments json.RawMessage) (string, error)
+
+// RunTools drives a single agent loop: it sends req, and for every tool call
+// the model requests, invokes the matching handler in handlers and appends
+// the result as a "tool" message, looping until the model replies without
+// requesting further tool calls or maxToolTurns is reached. This lets
+// callers build agent loops without reimplementing the delta-merging logic
+// used for streaming tool calls.
+func (c *Client) RunTools(ctx context.Context, req *ChatCompletionRequest, handlers map[string]ToolHandler) (*ChatCompletionResponse, error) {
+	const maxToolTurns = 10
+
+	messages := append([]ChatMessage(nil), req.Messages...)
+
+	for turn := 0; turn < maxToolTurns; turn++ {
+		req.Messages = messages
+
+		reqMap, err := reqToMap(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal chat completion request: %w", err)
+		}
+
+		resp, err := c.ChatCompletion(ctx, reqMap)
+		if err != nil {
+			return nil, err
+		}
+
+		typed, err := AsChatCompletion(resp)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(typed.Choices) == 0 || typed.Choices[0].Message == nil {
+			return typed, nil
+		}
+
+		choice := typed.Choices[0]
+		if choice.FinishReason != "tool_calls" || len(choice.Message.ToolCalls) == 0 {
+			return typed, nil
+		}
+
+		messages = append(messages, *choice.Message)
+		for _, call := range choice.Message.ToolCalls {
+			messages = append(messages, runOneTool(ctx, call, handlers))
+		}
+	}
+
+	return nil, fmt.Errorf("tool loop exceeded %d turns without a final answer", maxToolTurns)
+}
+
+// runOneTool invokes the handler registered for call and wraps the outcome
+// (or the absence of a handler / a handler error) as a "tool" role message.
+func runOneTool(ctx context.Context, call ToolCall, handlers map[string]ToolHandler) ChatMessage {
+	handler, ok := handlers[call.Function.Name]
+	if !ok {
+		return ChatMessage{
+			Role:       "tool",
+			ToolCallID: call.ID,
+			Content:    TextContent(fmt.Sprintf("error: no handler registered for tool %q", call.Function.Name)),
+		}
+	}
+
+	result, err := handler(ctx, json.RawMessage(call.Function.Arguments))
+	if err != nil {
+		result = fmt.Sprintf("error: %v", err)
+	}
+
+	return ChatMessage{
+		Role:       "tool",
+		ToolCallID: call.ID,
+		Content:    TextContent(result),
+	}
+}
+
+// ==================== Streaming Tool-Call Aggregation ====================
+
+// toolCallBuilder accumulates the fragmented delta.tool_calls[i].function.arguments
+// pieces that arrive across many SSE chunks, keyed by their index.
+type toolCallBuilder struct {
+	id   string
+	typ  string
+	name string
+	args strings.Builder
+}
+
+// captureToolCalls stitches together tool-call fragments from a single
+// streamed chunk into the reader's in-progress builders.
+func (s *TextStreamReader) captureToolCalls(chunk map[string]interface{}) {
+	choices, ok := chunk["choices"].([]interface{})
+	if !ok || len(choices) == 0 {
+		return
+	}
+	choice, ok := choices[0].(map[string]interface{})
+	if !ok {
+		return
+	}
+	delta, ok := choice["delta"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	fragments, ok := delta["tool_calls"].([]interface{})
+	if !ok {
+		return
+	}
+
+	if s.toolCalls == nil {
+		s.toolCalls = make(map[int]*toolCallBuilder)
+	}
+
+	for _, f := range fragments {
+		frag, ok := f.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		index := 0
+		if idx, ok := frag["index"].(float64); ok {
+			index = int(idx)
+		}
+
+		b, exists := s.toolCalls[index]
+		if !exists {
+			b = &toolCallBuilder{}
+			s.toolCalls[index] = b
+			s.toolOrder = append(s.toolOrder, index)
+		}
+
+		if id, ok := frag["id"].(string); ok && id != "" {
+			b.id = id
+		}
+		if typ, ok := frag["type"].(string); ok && typ != "" {
+			b.typ = typ
+		}
+		if fn, ok := frag["function"].(map[string]interface{}); ok {
+			if name, ok := fn["name"].(string); ok && name != "" {
+				b.name = name
+			}
+			if args, ok := fn["arguments"].(string); ok {
+				b.args.WriteString(args)
+			}
+		}
+	}
+}
+
+// ToolCalls returns the tool calls assembled so far from streamed deltas.
+// Call it once the stream finishes (Next returns io.EOF) or once a chunk
+// with finish_reason "tool_calls" has been observed, to get the complete,
+// JSON-parseable arguments for each call.
+func (s *TextStreamReader) ToolCalls() []ToolCall {
+	if len(s.toolOrder) == 0 {
+		return nil
+	}
+
+	calls := make([]ToolCall, 0, len(s.toolOrder))
+	for _, index := range s.toolOrder {
+		b := s.toolCalls[index]
+		call := ToolCall{ID: b.id, Type: b.typ}
+		call.Function.Name = b.name
+		call.Function.Arguments = b.args.String()
+		calls = append(calls, call)
+	}
+	return calls
+}
@@ -6,77 +6,326 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
+
+	"golang.org/x/time/rate"
 )
 
+// RetryPolicy controls DoRequest's retry schedule: full exponential
+// backoff (min(MaxDelay, MinDelay*2^(attempt-1)) plus jitter) instead of a
+// fixed linear schedule.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries after the initial
+	// attempt (0 = no retries).
+	MaxRetries int
+
+	// MinDelay is the backoff for the first retry; it doubles each
+	// attempt thereafter up to MaxDelay.
+	MinDelay time.Duration
+
+	// MaxDelay caps the computed backoff before jitter is applied.
+	MaxDelay time.Duration
+
+	// Jitter randomizes the computed backoff by up to this fraction
+	// (0.0-1.0) in either direction; see applyJitter.
+	Jitter float64
+}
+
+// DefaultRetryPolicy returns the retry schedule used when Config.RetryPolicy
+// is unset: DefaultMaxRetries retries, 500ms doubling up to 30s, with 20%
+// jitter.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: DefaultMaxRetries,
+		MinDelay:   500 * time.Millisecond,
+		MaxDelay:   30 * time.Second,
+		Jitter:     0.2,
+	}
+}
+
+// backoff computes the delay before the given retry attempt (1-indexed).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	minDelay := p.MinDelay
+	if minDelay <= 0 {
+		minDelay = 500 * time.Millisecond
+	}
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = 30 * time.Second
+	}
+
+	delay := minDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= maxDelay {
+			delay = maxDelay
+			break
+		}
+	}
+
+	return applyJitter(delay, p.Jitter)
+}
+
+// isRetryableStatus reports whether an HTTP status code should be retried:
+// 5xx and network-level failures always are, and so are 408 (request
+// timeout) and 429 (rate limited); other 4xx short-circuit.
+func isRetryableStatus(code int) bool {
+	if code == http.StatusRequestTimeout || code == http.StatusTooManyRequests {
+		return true
+	}
+	return code >= 500
+}
+
+// parseRetryAfter decodes a Retry-After header value, which per RFC 9110 is
+// either a non-negative integer number of seconds (delta-seconds) or an
+// HTTP-date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		d := time.Until(when)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
 // Transport handles HTTP communication with the API
 type Transport struct {
-	client     *http.Client
-	baseURL    string
-	apiKey     string
-	maxRetries int
-	headers    map[string]string
+	client          *http.Client
+	baseURL         string
+	auth            Authenticator
+	headers         map[string]string
+	logger          Logger
+	requestDump     io.Writer
+	limiter         *rate.Limiter
+	retryPolicy     RetryPolicy
+	onRequest       RequestHookFunc
+	onResponse      ResponseHookFunc
+	onRetry         RetryHookFunc
+	onError         ErrorHookFunc
+	cache           Cache
+	cacheDefaultTTL time.Duration
+	breaker         *circuitBreaker
 }
 
 // New creates a new transport instance
 func New(client *http.Client, cfg *Config) *Transport {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = noopLogger{}
+	}
+
+	retryPolicy := DefaultRetryPolicy()
+	if cfg.MaxRetries >= 0 {
+		retryPolicy.MaxRetries = cfg.MaxRetries
+	}
+	if cfg.RetryPolicy != nil {
+		retryPolicy = *cfg.RetryPolicy
+	}
+
+	auth := cfg.Authenticator
+	if auth == nil {
+		auth = BearerTokenAuth{Token: cfg.APIKey}
+	}
+
+	var breaker *circuitBreaker
+	if cfg.CircuitBreaker != nil {
+		breaker = newCircuitBreaker(*cfg.CircuitBreaker)
+	}
+
 	return &Transport{
-		client:     client,
-		baseURL:    cfg.BaseURL,
-		apiKey:     cfg.APIKey,
-		maxRetries: cfg.MaxRetries,
-		headers:    cfg.Headers,
+		client:          client,
+		baseURL:         cfg.BaseURL,
+		auth:            auth,
+		headers:         cfg.Headers,
+		logger:          logger,
+		requestDump:     cfg.RequestDump,
+		limiter:         cfg.RateLimiter,
+		retryPolicy:     retryPolicy,
+		onRequest:       cfg.OnRequest,
+		onResponse:      cfg.OnResponse,
+		onRetry:         cfg.OnRetry,
+		onError:         cfg.OnError,
+		cache:           cfg.Cache,
+		cacheDefaultTTL: cfg.CacheDefaultTTL,
+		breaker:         breaker,
 	}
 }
 
+// circuitState reports the breaker's current state, or CircuitClosed if no
+// circuit breaker was configured.
+func (t *Transport) circuitState() CircuitState {
+	if t.breaker == nil {
+		return CircuitClosed
+	}
+	return t.breaker.State()
+}
+
+// waitForRateLimit blocks until the configured RateLimiter admits one more
+// request; a no-op when no limiter is configured.
+func (t *Transport) waitForRateLimit(ctx context.Context) error {
+	if t.limiter == nil {
+		return nil
+	}
+	if err := t.limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limiter: %w", err)
+	}
+	return nil
+}
+
+// applyRateLimitCooldown decays the limiter to the server-advertised rate
+// (one request per retryAfter) for the cool-down window, then restores the
+// rate it had before. A no-op when no limiter is configured.
+func (t *Transport) applyRateLimitCooldown(retryAfter time.Duration) {
+	if t.limiter == nil || retryAfter <= 0 {
+		return
+	}
+
+	previous := t.limiter.Limit()
+	t.limiter.SetLimit(rate.Limit(1 / retryAfter.Seconds()))
+	time.AfterFunc(retryAfter, func() {
+		t.limiter.SetLimit(previous)
+	})
+}
+
+// requestLogMeta carries the fields doRequestOnce learns mid-flight (status
+// code, byte counts) back out to DoRequest/DoStreamRequest for logging.
+type requestLogMeta struct {
+	statusCode    int
+	requestBytes  int
+	responseBytes int
+}
+
 // DoRequest performs an HTTP request with retry logic
 func (t *Transport) DoRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	if t.breaker != nil && !t.breaker.allow() {
+		return ErrCircuitOpen
+	}
+
+	start := time.Now()
+	requestID := newRequestID()
+	model, jobType := requestLogFields(body)
+	t.logger.LogRequest(ctx, "request_id", requestID, "method", method, "path", path, "model", model, "job_type", jobType)
+
+	logResult := func(meta requestLogMeta, err error) {
+		attrs := []any{
+			"request_id", requestID,
+			"method", method,
+			"path", path,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"http_status", meta.statusCode,
+			"request_bytes", meta.requestBytes,
+			"response_bytes", meta.responseBytes,
+		}
+		if err != nil {
+			attrs = append(attrs, "error", err.Error())
+		}
+		t.logger.LogResponse(ctx, attrs...)
+	}
+
 	var lastErr error
+	var meta requestLogMeta
+	var retryAfter time.Duration
 
-	for attempt := 0; attempt <= t.maxRetries; attempt++ {
+	for attempt := 0; attempt <= t.retryPolicy.MaxRetries; attempt++ {
 		if attempt > 0 {
-			// Exponential backoff
-			backoff := time.Duration(attempt) * time.Second
+			delay := t.retryPolicy.backoff(attempt)
+			if retryAfter > 0 {
+				delay = retryAfter
+			}
+			if t.onRetry != nil {
+				t.onRetry(ctx, attempt, delay, lastErr)
+			}
 			select {
 			case <-ctx.Done():
+				if t.breaker != nil {
+					t.breaker.recordFailure()
+				}
 				return ctx.Err()
-			case <-time.After(backoff):
+			case <-time.After(delay):
 			}
 		}
 
-		err := t.doRequestOnce(ctx, method, path, body, result)
+		meta = requestLogMeta{}
+		err := t.doRequestOnce(ctx, method, path, body, result, &meta, attempt)
 		if err == nil {
+			logResult(meta, nil)
+			if t.breaker != nil {
+				t.breaker.recordSuccess()
+			}
 			return nil
 		}
 
-		// Check if error is retryable
+		retryAfter = 0
 		if httpErr, ok := err.(*HTTPError); ok {
-			// Don't retry client errors (4xx) except 429
-			if httpErr.StatusCode >= 400 && httpErr.StatusCode < 500 && httpErr.StatusCode != 429 {
+			if !isRetryableStatus(httpErr.StatusCode) {
+				logResult(meta, err)
+				if t.onError != nil {
+					t.onError(ctx, err)
+				}
+				if t.breaker != nil && isCircuitFailure(err) {
+					t.breaker.recordFailure()
+				}
 				return err
 			}
+			if httpErr.StatusCode == http.StatusTooManyRequests {
+				retryAfter = httpErr.RetryAfter
+				t.applyRateLimitCooldown(retryAfter)
+			}
 		}
 
 		lastErr = err
 	}
 
-	return fmt.Errorf("max retries exceeded: %w", lastErr)
+	logResult(meta, lastErr)
+	finalErr := fmt.Errorf("max retries exceeded: %w", lastErr)
+	if t.onError != nil {
+		t.onError(ctx, finalErr)
+	}
+	if t.breaker != nil && isCircuitFailure(lastErr) {
+		t.breaker.recordFailure()
+	}
+	return finalErr
 }
 
 // doRequestOnce performs a single HTTP request
-func (t *Transport) doRequestOnce(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+func (t *Transport) doRequestOnce(ctx context.Context, method, path string, body interface{}, result interface{}, meta *requestLogMeta, attempt int) error {
+	if err := t.waitForRateLimit(ctx); err != nil {
+		return err
+	}
+
 	url := t.baseURL + path
+	start := time.Now()
 
 	// Prepare request body
 	var reqBody io.Reader
+	var jsonData []byte
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		data, err := json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("failed to marshal request body: %w", err)
 		}
+		jsonData = data
 		reqBody = bytes.NewReader(jsonData)
 	}
+	meta.requestBytes = len(jsonData)
 
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
@@ -86,26 +335,68 @@ func (t *Transport) doRequestOnce(ctx context.Context, method, path string, body
 
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+t.apiKey)
 	req.Header.Set("User-Agent", "onethingai-go-sdk/1.0.0")
+	if err := t.auth.Apply(req); err != nil {
+		return fmt.Errorf("failed to apply authentication: %w", err)
+	}
 
 	// Add custom headers
 	for key, value := range t.headers {
 		req.Header.Set(key, value)
 	}
 
+	cacheable := method == http.MethodGet && t.cache != nil && !cacheDisabled(ctx)
+	var cacheKeyVal string
+	if cacheable {
+		cacheKeyVal = cacheKey(method, url, req.Header.Get("Authorization"))
+		if entry, ok := t.cache.Get(ctx, cacheKeyVal); ok && time.Now().Before(entry.Expiry) {
+			meta.statusCode = entry.StatusCode
+			meta.responseBytes = len(entry.Body)
+			if result != nil {
+				if err := json.Unmarshal(entry.Body, result); err != nil {
+					return fmt.Errorf("failed to unmarshal cached response: %w", err)
+				}
+			}
+			return nil
+		}
+	}
+
+	t.dumpRequest(method, url, jsonData)
+
+	reqLog := RequestLog{Method: method, URL: url, Headers: redactHeaders(req.Header), Body: jsonData, Attempt: attempt}
+	if t.onRequest != nil {
+		t.onRequest(ctx, &reqLog)
+	}
+
+	respLog := ResponseLog{Request: reqLog}
+	finish := func(err error) error {
+		respLog.Duration = time.Since(start)
+		respLog.Err = err
+		if t.onResponse != nil {
+			t.onResponse(ctx, &respLog)
+		}
+		return err
+	}
+
 	// Execute request
 	resp, err := t.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return finish(fmt.Errorf("failed to execute request: %w", err))
 	}
 	defer resp.Body.Close()
+	meta.statusCode = resp.StatusCode
+	respLog.StatusCode = resp.StatusCode
+	respLog.Headers = resp.Header.Clone()
 
 	// Read response body
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return finish(fmt.Errorf("failed to read response body: %w", err))
 	}
+	meta.responseBytes = len(respBody)
+	respLog.Body = respBody
+
+	t.dumpResponse(resp.StatusCode, respBody)
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
@@ -131,34 +422,66 @@ func (t *Transport) doRequestOnce(ctx context.Context, method, path string, body
 			errorMsg = string(respBody)
 		}
 
-		return &HTTPError{
+		var retryAfter time.Duration
+		if resp.StatusCode == http.StatusTooManyRequests {
+			retryAfter, _ = parseRetryAfter(resp.Header.Get("Retry-After"))
+		}
+
+		return finish(&HTTPError{
 			StatusCode: resp.StatusCode,
 			Message:    errorMsg,
 			Body:       string(respBody),
-		}
+			RetryAfter: retryAfter,
+		})
 	}
 
 	// Parse successful response
 	if result != nil {
 		if err := json.Unmarshal(respBody, result); err != nil {
-			return fmt.Errorf("failed to unmarshal response: %w", err)
+			return finish(fmt.Errorf("failed to unmarshal response: %w", err))
 		}
 	}
 
-	return nil
+	if cacheable {
+		if ttl := t.cacheTTL(ctx, resp.Header); ttl > 0 {
+			_ = t.cache.Set(ctx, cacheKeyVal, &CacheEntry{
+				StatusCode: resp.StatusCode,
+				Headers:    resp.Header.Clone(),
+				Body:       respBody,
+				Expiry:     time.Now().Add(ttl),
+			})
+		}
+	}
+
+	return finish(nil)
 }
 
 // DoStreamRequest performs a streaming HTTP request
 func (t *Transport) DoStreamRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	start := time.Now()
+	requestID := newRequestID()
+	model, jobType := requestLogFields(body)
+	t.logger.LogRequest(ctx, "request_id", requestID, "method", method, "path", path, "model", model, "job_type", jobType, "stream", true)
+
+	if err := t.waitForRateLimit(ctx); err != nil {
+		t.logger.LogResponse(ctx, "request_id", requestID, "method", method, "path", path, "duration_ms", time.Since(start).Milliseconds(), "error", err.Error())
+		if t.onError != nil {
+			t.onError(ctx, err)
+		}
+		return nil, err
+	}
+
 	url := t.baseURL + path
 
 	// Prepare request body
 	var reqBody io.Reader
+	var jsonData []byte
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		data, err := json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
+		jsonData = data
 		reqBody = bytes.NewReader(jsonData)
 	}
 
@@ -170,21 +493,38 @@ func (t *Transport) DoStreamRequest(ctx context.Context, method, path string, bo
 
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+t.apiKey)
 	req.Header.Set("Accept", "text/event-stream")
 	req.Header.Set("Cache-Control", "no-cache")
 	req.Header.Set("Connection", "keep-alive")
 	req.Header.Set("User-Agent", "onethingai-go-sdk/1.0.0")
+	if err := t.auth.Apply(req); err != nil {
+		return nil, fmt.Errorf("failed to apply authentication: %w", err)
+	}
 
 	// Add custom headers
 	for key, value := range t.headers {
 		req.Header.Set(key, value)
 	}
 
+	t.dumpRequest(method, url, jsonData)
+
+	reqLog := RequestLog{Method: method, URL: url, Headers: redactHeaders(req.Header), Body: jsonData, Attempt: 0}
+	if t.onRequest != nil {
+		t.onRequest(ctx, &reqLog)
+	}
+
 	// Execute request
 	resp, err := t.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		t.logger.LogResponse(ctx, "request_id", requestID, "method", method, "path", path, "duration_ms", time.Since(start).Milliseconds(), "error", err.Error())
+		wrapped := fmt.Errorf("failed to execute request: %w", err)
+		if t.onResponse != nil {
+			t.onResponse(ctx, &ResponseLog{Request: reqLog, Duration: time.Since(start), Err: wrapped})
+		}
+		if t.onError != nil {
+			t.onError(ctx, wrapped)
+		}
+		return nil, wrapped
 	}
 
 	// Check for error status codes
@@ -193,7 +533,15 @@ func (t *Transport) DoStreamRequest(ctx context.Context, method, path string, bo
 
 		respBody, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, fmt.Errorf("HTTP %d: failed to read error response", resp.StatusCode)
+			t.logger.LogResponse(ctx, "request_id", requestID, "method", method, "path", path, "duration_ms", time.Since(start).Milliseconds(), "http_status", resp.StatusCode, "error", "failed to read error response")
+			readErr := fmt.Errorf("HTTP %d: failed to read error response", resp.StatusCode)
+			if t.onResponse != nil {
+				t.onResponse(ctx, &ResponseLog{Request: reqLog, StatusCode: resp.StatusCode, Headers: resp.Header.Clone(), Duration: time.Since(start), Err: readErr})
+			}
+			if t.onError != nil {
+				t.onError(ctx, readErr)
+			}
+			return nil, readErr
 		}
 
 		var errorMsg string
@@ -216,12 +564,301 @@ func (t *Transport) DoStreamRequest(ctx context.Context, method, path string, bo
 			errorMsg = string(respBody)
 		}
 
-		return nil, &HTTPError{
+		t.logger.LogResponse(ctx, "request_id", requestID, "method", method, "path", path, "duration_ms", time.Since(start).Milliseconds(), "http_status", resp.StatusCode, "response_bytes", len(respBody), "error", errorMsg)
+
+		httpErr := &HTTPError{
 			StatusCode: resp.StatusCode,
 			Message:    errorMsg,
 			Body:       string(respBody),
 		}
+		if t.onResponse != nil {
+			t.onResponse(ctx, &ResponseLog{Request: reqLog, StatusCode: resp.StatusCode, Headers: resp.Header.Clone(), Body: respBody, Duration: time.Since(start), Err: httpErr})
+		}
+		if t.onError != nil {
+			t.onError(ctx, httpErr)
+		}
+		return nil, httpErr
+	}
+
+	t.logger.LogResponse(ctx, "request_id", requestID, "method", method, "path", path, "duration_ms", time.Since(start).Milliseconds(), "http_status", resp.StatusCode, "request_bytes", len(jsonData))
+
+	if t.onResponse != nil {
+		t.onResponse(ctx, &ResponseLog{Request: reqLog, StatusCode: resp.StatusCode, Headers: resp.Header.Clone(), Duration: time.Since(start)})
+	}
+
+	return resp, nil
+}
+
+// FileField is one file part of a multipart/form-data request built by
+// DoMultipartRequest. ContentType may be left empty, in which case
+// multipart.Writer's CreateFormFile default ("application/octet-stream")
+// applies.
+type FileField struct {
+	Name        string
+	Filename    string
+	ContentType string
+	Reader      io.Reader
+}
+
+// DoMultipartRequest performs a multipart/form-data request, writing
+// fields and files through a multipart.Writer into an io.Pipe that feeds
+// the request body directly, so large file uploads (e.g. a ControlNet
+// reference image) are streamed rather than fully buffered in memory. The
+// caller is responsible for closing the returned response's body.
+//
+// Like DoStreamRequest, the streamed body can't be replayed, so this makes
+// a single attempt rather than going through the retry loop; it still
+// participates in the circuit breaker and the request/response/error hooks
+// so multipart uploads are as observable as the JSON request path.
+func (t *Transport) DoMultipartRequest(ctx context.Context, method, path string, fields url.Values, files []FileField) (*http.Response, error) {
+	if t.breaker != nil && !t.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	start := time.Now()
+	requestID := newRequestID()
+	t.logger.LogRequest(ctx, "request_id", requestID, "method", method, "path", path, "multipart", true)
+
+	if err := t.waitForRateLimit(ctx); err != nil {
+		t.logger.LogResponse(ctx, "request_id", requestID, "method", method, "path", path, "duration_ms", time.Since(start).Milliseconds(), "error", err.Error())
+		if t.onError != nil {
+			t.onError(ctx, err)
+		}
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(writeMultipartBody(writer, fields, files))
+	}()
+
+	url := t.baseURL + path
+	req, err := http.NewRequestWithContext(ctx, method, url, pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("User-Agent", "onethingai-go-sdk/1.0.0")
+	if err := t.auth.Apply(req); err != nil {
+		return nil, fmt.Errorf("failed to apply authentication: %w", err)
+	}
+	for key, value := range t.headers {
+		req.Header.Set(key, value)
+	}
+
+	reqLog := RequestLog{Method: method, URL: url, Headers: redactHeaders(req.Header), Attempt: 0}
+	if t.onRequest != nil {
+		t.onRequest(ctx, &reqLog)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		wrapped := fmt.Errorf("failed to execute request: %w", err)
+		t.logger.LogResponse(ctx, "request_id", requestID, "method", method, "path", path, "duration_ms", time.Since(start).Milliseconds(), "error", wrapped.Error())
+		if t.onResponse != nil {
+			t.onResponse(ctx, &ResponseLog{Request: reqLog, Duration: time.Since(start), Err: wrapped})
+		}
+		if t.onError != nil {
+			t.onError(ctx, wrapped)
+		}
+		if t.breaker != nil {
+			t.breaker.recordFailure()
+		}
+		return nil, wrapped
 	}
 
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, readErr := io.ReadAll(resp.Body)
+		if readErr != nil {
+			err := fmt.Errorf("HTTP %d: failed to read error response", resp.StatusCode)
+			t.logger.LogResponse(ctx, "request_id", requestID, "method", method, "path", path, "duration_ms", time.Since(start).Milliseconds(), "http_status", resp.StatusCode, "error", err.Error())
+			if t.onResponse != nil {
+				t.onResponse(ctx, &ResponseLog{Request: reqLog, StatusCode: resp.StatusCode, Headers: resp.Header.Clone(), Duration: time.Since(start), Err: err})
+			}
+			if t.onError != nil {
+				t.onError(ctx, err)
+			}
+			if t.breaker != nil {
+				t.breaker.recordFailure()
+			}
+			return nil, err
+		}
+
+		httpErr := &HTTPError{StatusCode: resp.StatusCode, Message: string(respBody), Body: string(respBody)}
+		t.logger.LogResponse(ctx, "request_id", requestID, "method", method, "path", path, "duration_ms", time.Since(start).Milliseconds(), "http_status", resp.StatusCode, "response_bytes", len(respBody), "error", httpErr.Error())
+		if t.onResponse != nil {
+			t.onResponse(ctx, &ResponseLog{Request: reqLog, StatusCode: resp.StatusCode, Headers: resp.Header.Clone(), Body: respBody, Duration: time.Since(start), Err: httpErr})
+		}
+		if t.onError != nil {
+			t.onError(ctx, httpErr)
+		}
+		if t.breaker != nil && isCircuitFailure(httpErr) {
+			t.breaker.recordFailure()
+		}
+		return nil, httpErr
+	}
+
+	t.logger.LogResponse(ctx, "request_id", requestID, "method", method, "path", path, "duration_ms", time.Since(start).Milliseconds(), "http_status", resp.StatusCode)
+	if t.onResponse != nil {
+		t.onResponse(ctx, &ResponseLog{Request: reqLog, StatusCode: resp.StatusCode, Headers: resp.Header.Clone(), Duration: time.Since(start)})
+	}
+	if t.breaker != nil {
+		t.breaker.recordSuccess()
+	}
 	return resp, nil
 }
+
+// DoRawRequest performs a JSON-bodied request expecting a non-JSON (e.g.
+// binary audio) response, returning the raw *http.Response for the caller
+// to stream and close. Like DoMultipartRequest it makes a single attempt
+// with breaker/hook/logging integration; unlike DoStreamRequest it sends no
+// SSE-specific headers.
+func (t *Transport) DoRawRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	if t.breaker != nil && !t.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	start := time.Now()
+	requestID := newRequestID()
+	model, jobType := requestLogFields(body)
+	t.logger.LogRequest(ctx, "request_id", requestID, "method", method, "path", path, "model", model, "job_type", jobType)
+
+	if err := t.waitForRateLimit(ctx); err != nil {
+		t.logger.LogResponse(ctx, "request_id", requestID, "method", method, "path", path, "duration_ms", time.Since(start).Milliseconds(), "error", err.Error())
+		if t.onError != nil {
+			t.onError(ctx, err)
+		}
+		return nil, err
+	}
+
+	url := t.baseURL + path
+	var reqBody io.Reader
+	var jsonData []byte
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		jsonData = data
+		reqBody = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "onethingai-go-sdk/1.0.0")
+	if err := t.auth.Apply(req); err != nil {
+		return nil, fmt.Errorf("failed to apply authentication: %w", err)
+	}
+	for key, value := range t.headers {
+		req.Header.Set(key, value)
+	}
+
+	t.dumpRequest(method, url, jsonData)
+
+	reqLog := RequestLog{Method: method, URL: url, Headers: redactHeaders(req.Header), Body: jsonData, Attempt: 0}
+	if t.onRequest != nil {
+		t.onRequest(ctx, &reqLog)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		wrapped := fmt.Errorf("failed to execute request: %w", err)
+		t.logger.LogResponse(ctx, "request_id", requestID, "method", method, "path", path, "duration_ms", time.Since(start).Milliseconds(), "error", wrapped.Error())
+		if t.onResponse != nil {
+			t.onResponse(ctx, &ResponseLog{Request: reqLog, Duration: time.Since(start), Err: wrapped})
+		}
+		if t.onError != nil {
+			t.onError(ctx, wrapped)
+		}
+		if t.breaker != nil {
+			t.breaker.recordFailure()
+		}
+		return nil, wrapped
+	}
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		httpErr := &HTTPError{StatusCode: resp.StatusCode, Message: string(respBody), Body: string(respBody)}
+		t.logger.LogResponse(ctx, "request_id", requestID, "method", method, "path", path, "duration_ms", time.Since(start).Milliseconds(), "http_status", resp.StatusCode, "response_bytes", len(respBody), "error", httpErr.Error())
+		if t.onResponse != nil {
+			t.onResponse(ctx, &ResponseLog{Request: reqLog, StatusCode: resp.StatusCode, Headers: resp.Header.Clone(), Body: respBody, Duration: time.Since(start), Err: httpErr})
+		}
+		if t.onError != nil {
+			t.onError(ctx, httpErr)
+		}
+		if t.breaker != nil && isCircuitFailure(httpErr) {
+			t.breaker.recordFailure()
+		}
+		return nil, httpErr
+	}
+
+	t.logger.LogResponse(ctx, "request_id", requestID, "method", method, "path", path, "duration_ms", time.Since(start).Milliseconds(), "http_status", resp.StatusCode, "request_bytes", len(jsonData))
+	if t.onResponse != nil {
+		t.onResponse(ctx, &ResponseLog{Request: reqLog, StatusCode: resp.StatusCode, Headers: resp.Header.Clone(), Duration: time.Since(start)})
+	}
+	if t.breaker != nil {
+		t.breaker.recordSuccess()
+	}
+	return resp, nil
+}
+
+// writeMultipartBody writes fields then files to writer and closes it,
+// returning the first error encountered (if any), to be delivered to the
+// pipe reader via PipeWriter.CloseWithError.
+func writeMultipartBody(writer *multipart.Writer, fields url.Values, files []FileField) error {
+	for name, values := range fields {
+		for _, value := range values {
+			if err := writer.WriteField(name, value); err != nil {
+				return fmt.Errorf("failed to write multipart field %q: %w", name, err)
+			}
+		}
+	}
+
+	for _, f := range files {
+		var part io.Writer
+		var err error
+		if f.ContentType != "" {
+			header := make(textproto.MIMEHeader)
+			header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, f.Name, f.Filename))
+			header.Set("Content-Type", f.ContentType)
+			part, err = writer.CreatePart(header)
+		} else {
+			part, err = writer.CreateFormFile(f.Name, f.Filename)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create multipart file field %q: %w", f.Name, err)
+		}
+		if _, err := io.Copy(part, f.Reader); err != nil {
+			return fmt.Errorf("failed to copy file data for %q: %w", f.Name, err)
+		}
+	}
+
+	return writer.Close()
+}
+
+// dumpRequest writes the full, unredacted request to t.requestDump when
+// WithRequestDump is configured. Local debugging only: unlike the
+// structured logger, this does not truncate B64JSON fields or redact
+// credentials.
+func (t *Transport) dumpRequest(method, url string, body []byte) {
+	if t.requestDump == nil {
+		return
+	}
+	fmt.Fprintf(t.requestDump, "--> %s %s\n%s\n\n", method, url, body)
+}
+
+// dumpResponse writes the full, unredacted response to t.requestDump when
+// WithRequestDump is configured.
+func (t *Transport) dumpResponse(statusCode int, body []byte) {
+	if t.requestDump == nil {
+		return
+	}
+	fmt.Fprintf(t.requestDump, "<-- %d\n%s\n\n", statusCode, body)
+}
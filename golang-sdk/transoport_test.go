@@ -0,0 +1,90 @@
+package onethingai
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoffDoublesUpToMax(t *testing.T) {
+	p := RetryPolicy{MinDelay: time.Second, MaxDelay: 8 * time.Second, Jitter: 0}
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second}
+	for i, w := range want {
+		attempt := i + 1
+		if got := p.backoff(attempt); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", attempt, got, w)
+		}
+	}
+}
+
+func TestRetryPolicyBackoffAppliesDefaults(t *testing.T) {
+	p := RetryPolicy{}
+	got := p.backoff(1)
+	if got != 500*time.Millisecond {
+		t.Errorf("backoff(1) with zero-value policy = %v, want 500ms default MinDelay", got)
+	}
+}
+
+func TestRetryPolicyBackoffWithinJitterBounds(t *testing.T) {
+	p := RetryPolicy{MinDelay: 2 * time.Second, MaxDelay: 2 * time.Second, Jitter: 0.5}
+	for i := 0; i < 50; i++ {
+		got := p.backoff(1)
+		if got < 0 {
+			t.Fatalf("backoff() = %v, want >= 0", got)
+		}
+		if got > 3*time.Second {
+			t.Fatalf("backoff() = %v, want <= 3s (2s + 50%% jitter)", got)
+		}
+	}
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	cases := []struct {
+		code int
+		want bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusBadRequest, false},
+		{http.StatusUnauthorized, false},
+		{http.StatusRequestTimeout, true},
+		{http.StatusTooManyRequests, true},
+		{http.StatusInternalServerError, true},
+		{http.StatusBadGateway, true},
+	}
+	for _, c := range cases {
+		if got := isRetryableStatus(c.code); got != c.want {
+			t.Errorf("isRetryableStatus(%d) = %v, want %v", c.code, got, c.want)
+		}
+	}
+}
+
+func TestParseRetryAfterDeltaSeconds(t *testing.T) {
+	got, ok := parseRetryAfter("120")
+	if !ok || got != 120*time.Second {
+		t.Errorf("parseRetryAfter(\"120\") = %v, %v, want 120s, true", got, ok)
+	}
+}
+
+func TestParseRetryAfterNegativeIsInvalid(t *testing.T) {
+	if _, ok := parseRetryAfter("-5"); ok {
+		t.Error("parseRetryAfter(\"-5\") ok = true, want false")
+	}
+}
+
+func TestParseRetryAfterEmptyIsInvalid(t *testing.T) {
+	if _, ok := parseRetryAfter(""); ok {
+		t.Error("parseRetryAfter(\"\") ok = true, want false")
+	}
+}
+
+func TestParseRetryAfterHTTPDate(t *testing.T) {
+	future := time.Now().Add(time.Hour).UTC()
+	got, ok := parseRetryAfter(future.Format(http.TimeFormat))
+	if !ok {
+		t.Fatal("parseRetryAfter(HTTP-date) ok = false, want true")
+	}
+	if got <= 0 || got > time.Hour+time.Minute {
+		t.Errorf("parseRetryAfter(HTTP-date) = %v, want ~1h", got)
+	}
+}
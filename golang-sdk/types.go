@@ -3,6 +3,7 @@ package onethingai
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
 // ==================== Request Types ====================
@@ -23,7 +24,8 @@ type InputImage struct {
 
 // InputVideo 输入视频结构
 type InputVideo struct {
-	URL *string `json:"url,omitempty"`
+	URL     *string `json:"url,omitempty"`
+	B64JSON *string `json:"b64_json,omitempty"`
 }
 
 // ResponseFormat 响应格式枚举
@@ -68,23 +70,97 @@ const (
 type ImageOutputConfig struct {
 	Height         *int            `json:"height,omitempty"`
 	Width          *int            `json:"width,omitempty"`
+	Steps          *int            `json:"steps,omitempty"`          // 采样步数
+	GuidanceScale  *float64        `json:"guidance_scale,omitempty"` // 提示词引导强度
 	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
 }
 
 // VideoOutputConfig 视频输出配置
 type VideoOutputConfig struct {
-	Height   *int `json:"height,omitempty"`
-	Width    *int `json:"width,omitempty"`
-	Duration *int `json:"duration,omitempty"` // 视频时长（秒）
-	Fps      *int `json:"fps,omitempty"`      // 视频帧率
+	Height      *int         `json:"height,omitempty"`
+	Width       *int         `json:"width,omitempty"`
+	Duration    *int         `json:"duration,omitempty"` // 视频时长（秒）
+	Fps         *int         `json:"fps,omitempty"`      // 视频帧率
+	PostProcess *PostProcess `json:"post_process,omitempty"`
+}
+
+// PostProcess requests derived assets be generated alongside the main
+// video: an animated GIF/WebP, a sprite sheet, a trimmed clip, and/or the
+// extracted audio track. Each field is independent and optional; the
+// results land in VideoResult.Derived.
+type PostProcess struct {
+	AnimatedGraphics *AnimatedGraphicsParam `json:"animated_graphics,omitempty"`
+	Sprite           *SpriteParam           `json:"sprite,omitempty"`
+	Clip             *ClipParam             `json:"clip,omitempty"`
+	StreamExtract    *StreamExtractParam    `json:"stream_extract,omitempty"`
+}
+
+// AnimatedGraphicsFormat 动图输出格式枚举
+type AnimatedGraphicsFormat string
+
+const (
+	AnimatedGraphicsFormatGIF  AnimatedGraphicsFormat = "gif"
+	AnimatedGraphicsFormatWebP AnimatedGraphicsFormat = "webp"
+)
+
+// AnimatedGraphicsParam requests an animated GIF/WebP cut from [Start, End]
+// (milliseconds, End-Start <= 60000). Width/Height follow the common
+// auto-fit convention: 0 keeps the source value, -1 scales to preserve
+// aspect ratio against the other dimension, and any other value must fall
+// in [32, 3840]. FrameRate must fall in [1, 75].
+type AnimatedGraphicsParam struct {
+	Format    AnimatedGraphicsFormat `json:"format"`
+	Start     int                    `json:"start"`      // 起始时间（毫秒）
+	End       int                    `json:"end"`         // 结束时间（毫秒）
+	Width     int                    `json:"width"`       // 0=保持原值，-1=按比例自适应
+	Height    int                    `json:"height"`      // 0=保持原值，-1=按比例自适应
+	FrameRate int                    `json:"frame_rate"`  // 帧率 [1,75]
+}
+
+// SpriteParam requests a thumbnail grid sampled across the video.
+type SpriteParam struct {
+	Columns  int `json:"columns"`            // 每行缩略图数
+	Rows     int `json:"rows"`               // 每列缩略图数
+	Width    int `json:"width,omitempty"`    // 单张缩略图宽度，0 表示自动
+	Interval int `json:"interval,omitempty"` // 采样间隔（毫秒），0 表示均匀采样
+}
+
+// ClipParam requests a trimmed sub-clip of the main video.
+type ClipParam struct {
+	Start    int `json:"start"`    // 起始时间（毫秒）
+	Duration int `json:"duration"` // 片段时长（毫秒），上限 60000
+}
+
+// StreamExtractParam requests the audio track be pulled out as a separate
+// asset.
+type StreamExtractParam struct {
+	Format string `json:"format,omitempty"` // 如 "mp3"、"aac"，为空表示使用源格式
+}
+
+// DerivedAssetKind 派生资源类型枚举
+type DerivedAssetKind string
+
+const (
+	DerivedAssetAnimatedGraphics DerivedAssetKind = "animated_graphics"
+	DerivedAssetSprite           DerivedAssetKind = "sprite"
+	DerivedAssetClip             DerivedAssetKind = "clip"
+	DerivedAssetAudio            DerivedAssetKind = "audio"
+)
+
+// DerivedAsset is one post-processed asset produced alongside a VideoResult.
+type DerivedAsset struct {
+	Kind     DerivedAssetKind       `json:"kind"`
+	URL      string                 `json:"url"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // ==================== Extra Params ====================
 
 // ImageExtra 图片额外参数
 type ImageExtra struct {
-	Seed  *int        `json:"seed,omitempty"`
-	Style *ImageStyle `json:"style,omitempty"`
+	Seed           *int        `json:"seed,omitempty"`
+	Style          *ImageStyle `json:"style,omitempty"`
+	NegativePrompt string      `json:"negative_prompt,omitempty"` // 反向提示词
 }
 
 // ImageStyle 图片风格枚举
@@ -130,14 +206,31 @@ type Parameters[T any] struct {
 
 // UnifiedRequest 统一请求结构
 type UnifiedRequest[T any, F any, S any] struct {
-	Model      string         `json:"model"`                // 使用的模型或引擎的唯一标识符
-	JobType    F              `json:"job_type"`             // 任务类型
-	SyncMode   SyncMode       `json:"sync_mode"`            // 响应模式
-	Stream     *bool          `json:"stream,omitempty"`     // 是否启用流式响应
-	Prompt     string         `json:"prompt"`               // 核心文本描述或编辑指令
-	N          *int           `json:"n,omitempty"`          // 期望生成的图片/视频数量
-	Parameters *Parameters[T] `json:"parameters,omitempty"` // 结构化通用配置
-	Extra      *S             `json:"extra,omitempty"`      // 额外参数
+	Model      string          `json:"model"`                // 使用的模型或引擎的唯一标识符
+	JobType    F               `json:"job_type"`             // 任务类型
+	SyncMode   SyncMode        `json:"sync_mode"`            // 响应模式
+	Stream     *bool           `json:"stream,omitempty"`     // 是否启用流式响应
+	Prompt     string          `json:"prompt"`               // 核心文本描述或编辑指令
+	N          *int            `json:"n,omitempty"`          // 期望生成的图片/视频数量
+	Parameters *Parameters[T]  `json:"parameters,omitempty"` // 结构化通用配置
+	Extra      *S              `json:"extra,omitempty"`      // 额外参数
+	Callback   *CallbackConfig `json:"callback,omitempty"`   // 异步任务完成后的 webhook 回调配置
+
+	// Attachments carries reference images (img2img, inpainting,
+	// ControlNet, ...) to upload as multipart/form-data instead of
+	// base64-encoding them into the JSON body; see
+	// Client.GenerateImageWithFiles and Transport.DoMultipartRequest.
+	// Never serialized into the JSON request body itself.
+	Attachments []FileField `json:"-"`
+}
+
+// CallbackConfig describes a webhook to push the job's result to instead of
+// (or in addition to) polling it. Set on UnifiedRequest.Callback; see
+// WebhookServer for the receiving side.
+type CallbackConfig struct {
+	URL    string            `json:"url"`              // 回调地址
+	Secret string            `json:"secret,omitempty"` // 用于 HMAC-SHA256 签名回调请求体的共享密钥
+	Events []StreamEventType `json:"events,omitempty"` // 触发回调的事件类型，为空表示仅在任务终态时回调
 }
 
 // ImageRequest 图片请求类型
@@ -217,6 +310,25 @@ func (r *ImageAndVideoDataResponse[T]) IsProcessing() bool {
 	return r.Status == StatusProcessing
 }
 
+// EstimatedCompletion linearly extrapolates a completion time from Created
+// and Progress, assuming a constant rate of progress since the job started.
+// It reuses estimatePollETA's remaining-time formula (the one WaitForImage's
+// adaptive polling uses between polls), treating the job's entire lifetime
+// as a single poll interval starting from 0 progress. Returns the zero
+// time.Time if Progress hasn't advanced yet.
+func (r *ImageAndVideoDataResponse[T]) EstimatedCompletion() time.Time {
+	if r.Progress <= 0 {
+		return time.Time{}
+	}
+	created := time.Unix(r.Created, 0)
+	elapsed := time.Since(created)
+	if elapsed <= 0 {
+		return time.Time{}
+	}
+	remaining := estimatePollETA(r.Progress, 0, elapsed)
+	return created.Add(elapsed).Add(remaining)
+}
+
 // ImageDataResponse 图片响应类型
 type ImageDataResponse = ImageAndVideoDataResponse[ImageResult]
 
@@ -256,6 +368,7 @@ type VideoResult struct {
 	Duration *int                   `json:"duration,omitempty"` // 视频时长（秒）
 	Fps      *int                   `json:"fps,omitempty"`      // 视频帧率
 	Metadata map[string]interface{} `json:"metadata,omitempty"` // 结果相关的元数据
+	Derived  []DerivedAsset         `json:"derived,omitempty"`  // PostProcess 生成的派生资源（动图/雪碧图/裁剪片段/音轨）
 }
 
 // GetURL returns the video URL or empty string
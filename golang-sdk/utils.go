@@ -1,9 +1,12 @@
 package onethingai
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/base64"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"strings"
 )
@@ -38,9 +41,21 @@ func FileToInputImage(filePath string) (InputImage, error) {
 	return InputImage{B64JSON: &b64}, nil
 }
 
-// ReaderToInputImage reads from an io.Reader and converts it to base64 InputImage
+// ReaderToInputImage reads from an io.Reader and converts it to base64
+// InputImage. contentType may be left empty; the first 512 bytes are then
+// sniffed to determine it before the stream is fully consumed for encoding.
 func ReaderToInputImage(reader io.Reader, contentType string) (InputImage, error) {
-	data, err := io.ReadAll(reader)
+	br := bufio.NewReader(reader)
+
+	if contentType == "" {
+		peek, err := br.Peek(512)
+		if err != nil && err != io.EOF {
+			return InputImage{}, fmt.Errorf("failed to sniff content type: %w", err)
+		}
+		contentType = sniffImageContentType(peek)
+	}
+
+	data, err := io.ReadAll(br)
 	if err != nil {
 		return InputImage{}, fmt.Errorf("failed to read data: %w", err)
 	}
@@ -51,8 +66,59 @@ func ReaderToInputImage(reader io.Reader, contentType string) (InputImage, error
 	return InputImage{B64JSON: &b64}, nil
 }
 
-// detectContentType detects content type from file extension
+// detectContentType detects an image's content type by sniffing its magic
+// bytes, falling back to the file extension only when sniffing can't tell
+// (neither the image magic-number table nor http.DetectContentType
+// recognizes the bytes, i.e. the latter returns application/octet-stream).
 func detectContentType(filePath string) string {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return detectContentTypeFromExtension(filePath)
+	}
+	defer f.Close()
+
+	buf := make([]byte, 512)
+	n, _ := f.Read(buf)
+
+	return sniffImageContentTypeWithFallback(buf[:n], filePath)
+}
+
+// sniffImageContentType inspects the first bytes of an image for known
+// magic numbers (JPEG, PNG, GIF, WEBP, BMP), falling back to
+// http.DetectContentType and finally "image/jpeg" if nothing matches.
+func sniffImageContentType(data []byte) string {
+	return sniffImageContentTypeWithFallback(data, "")
+}
+
+// sniffImageContentTypeWithFallback is sniffImageContentType plus an
+// extension-based fallback for callers (like detectContentType) that know
+// the original file name.
+func sniffImageContentTypeWithFallback(data []byte, filePath string) string {
+	switch {
+	case len(data) >= 3 && data[0] == 0xFF && data[1] == 0xD8 && data[2] == 0xFF:
+		return "image/jpeg"
+	case len(data) >= 8 && bytes.Equal(data[:8], []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}):
+		return "image/png"
+	case len(data) >= 6 && (string(data[:6]) == "GIF87a" || string(data[:6]) == "GIF89a"):
+		return "image/gif"
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return "image/webp"
+	case len(data) >= 2 && data[0] == 0x42 && data[1] == 0x4D:
+		return "image/bmp"
+	}
+
+	if ct := http.DetectContentType(data); ct != "application/octet-stream" {
+		return ct
+	}
+
+	if filePath != "" {
+		return detectContentTypeFromExtension(filePath)
+	}
+	return "image/jpeg"
+}
+
+// detectContentTypeFromExtension detects content type from file extension
+func detectContentTypeFromExtension(filePath string) string {
 	ext := strings.ToLower(filePath[strings.LastIndex(filePath, ".")+1:])
 
 	switch ext {
@@ -78,6 +144,69 @@ func URLToInputVideo(url string) InputVideo {
 	return InputVideo{URL: &url}
 }
 
+// FileToInputVideo reads a local video file and inlines it as a base64 data
+// URL, refusing files above DefaultMaxInlineVideoBytes so callers are pushed
+// toward URL inputs instead.
+func FileToInputVideo(filePath string) (InputVideo, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return InputVideo{}, fmt.Errorf("failed to stat file: %w", err)
+	}
+	if info.Size() > DefaultMaxInlineVideoBytes {
+		return InputVideo{}, NewValidationError("file", fmt.Sprintf("video file is %d bytes, exceeds the %d byte inline limit; use a URL input instead", info.Size(), DefaultMaxInlineVideoBytes))
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return InputVideo{}, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	contentType := detectVideoContentType(filePath)
+	b64 := fmt.Sprintf("data:%s;base64,%s", contentType, base64.StdEncoding.EncodeToString(data))
+	return InputVideo{B64JSON: &b64}, nil
+}
+
+// ReaderToInputVideo reads from an io.Reader and inlines it as a base64 data
+// URL, subject to the same inline size cap as FileToInputVideo.
+func ReaderToInputVideo(reader io.Reader, contentType string) (InputVideo, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, DefaultMaxInlineVideoBytes+1))
+	if err != nil {
+		return InputVideo{}, fmt.Errorf("failed to read data: %w", err)
+	}
+	if int64(len(data)) > DefaultMaxInlineVideoBytes {
+		return InputVideo{}, NewValidationError("reader", fmt.Sprintf("video payload exceeds the %d byte inline limit; use a URL input instead", DefaultMaxInlineVideoBytes))
+	}
+
+	if contentType == "" {
+		contentType = "video/mp4"
+	}
+
+	b64 := fmt.Sprintf("data:%s;base64,%s", contentType, base64.StdEncoding.EncodeToString(data))
+	return InputVideo{B64JSON: &b64}, nil
+}
+
+// detectVideoContentType detects a video's content type from its file extension
+func detectVideoContentType(filePath string) string {
+	ext := strings.ToLower(filePath[strings.LastIndex(filePath, ".")+1:])
+
+	switch ext {
+	case "mp4":
+		return "video/mp4"
+	case "webm":
+		return "video/webm"
+	case "mov", "quicktime":
+		return "video/quicktime"
+	case "mkv":
+		return "video/x-matroska"
+	case "avi":
+		return "video/x-msvideo"
+	case "gif":
+		return "image/gif"
+	default:
+		return "video/mp4"
+	}
+}
+
 // ==================== Size Helpers ====================
 
 // Common image sizes
@@ -231,13 +360,6 @@ func GetAllVideoURLs(response *VideoDataResponse) []string {
 }
 
 // ==================== Debug Helpers ====================
-
-// DebugRequest returns a string representation of a request for debugging
-func DebugRequest(req interface{}) string {
-	return fmt.Sprintf("%+v", req)
-}
-
-// DebugResponse returns a string representation of a response for debugging
-func DebugResponse(resp interface{}) string {
-	return fmt.Sprintf("%+v", resp)
-}
+//
+// DebugRequest/DebugResponse now live in logging.go, backed by the
+// structured Logger (see WithLogger) instead of a bare fmt.Sprintf.
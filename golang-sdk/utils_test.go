@@ -0,0 +1,87 @@
+package onethingai
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileToInputVideo(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "clip.mp4")
+	data := []byte("fake mp4 bytes")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	input, err := FileToInputVideo(path)
+	if err != nil {
+		t.Fatalf("FileToInputVideo: %v", err)
+	}
+	if input.B64JSON == nil {
+		t.Fatal("expected B64JSON to be set")
+	}
+	if !strings.HasPrefix(*input.B64JSON, "data:video/mp4;base64,") {
+		t.Errorf("unexpected data URL prefix: %s", *input.B64JSON)
+	}
+
+	encoded := (*input.B64JSON)[strings.Index(*input.B64JSON, ",")+1:]
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	if !bytes.Equal(decoded, data) {
+		t.Errorf("decoded data = %q, want %q", decoded, data)
+	}
+}
+
+func TestFileToInputVideoTooLarge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "big.mp4")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := f.Truncate(DefaultMaxInlineVideoBytes + 1); err != nil {
+		t.Fatalf("Truncate: %v", err)
+	}
+	f.Close()
+
+	if _, err := FileToInputVideo(path); err == nil {
+		t.Fatal("expected an error for a file exceeding the inline limit")
+	}
+}
+
+func TestReaderToInputVideo(t *testing.T) {
+	data := []byte("fake webm bytes")
+	input, err := ReaderToInputVideo(bytes.NewReader(data), "video/webm")
+	if err != nil {
+		t.Fatalf("ReaderToInputVideo: %v", err)
+	}
+	if input.B64JSON == nil {
+		t.Fatal("expected B64JSON to be set")
+	}
+	if !strings.HasPrefix(*input.B64JSON, "data:video/webm;base64,") {
+		t.Errorf("unexpected data URL prefix: %s", *input.B64JSON)
+	}
+}
+
+func TestReaderToInputVideoDefaultContentType(t *testing.T) {
+	input, err := ReaderToInputVideo(bytes.NewReader([]byte("data")), "")
+	if err != nil {
+		t.Fatalf("ReaderToInputVideo: %v", err)
+	}
+	if !strings.HasPrefix(*input.B64JSON, "data:video/mp4;base64,") {
+		t.Errorf("expected default content type video/mp4, got %s", *input.B64JSON)
+	}
+}
+
+func TestReaderToInputVideoTooLarge(t *testing.T) {
+	reader := bytes.NewReader(make([]byte, DefaultMaxInlineVideoBytes+1))
+	if _, err := ReaderToInputVideo(reader, "video/mp4"); err == nil {
+		t.Fatal("expected an error for a payload exceeding the inline limit")
+	}
+}
@@ -22,6 +22,12 @@ func validateVideoRequest(req interface{}, syncMode SyncMode) error {
 			r.JobType = VideoJobTypeText2Video
 		}
 		r.Stream = nil
+
+		if r.Parameters != nil && r.Parameters.OutputConfig != nil && r.Parameters.OutputConfig.PostProcess != nil {
+			if err := validatePostProcess(r.Parameters.OutputConfig.PostProcess); err != nil {
+				return err
+			}
+		}
 		return nil
 
 	case map[string]interface{}:
@@ -45,6 +51,42 @@ func validateVideoRequest(req interface{}, syncMode SyncMode) error {
 	}
 }
 
+// validatePostProcess enforces the auto-fit (0/-1/[32,3840]) and range rules
+// documented on AnimatedGraphicsParam and the 60-second cap on ClipParam.
+func validatePostProcess(pp *PostProcess) error {
+	if ag := pp.AnimatedGraphics; ag != nil {
+		if ag.End-ag.Start > 60000 {
+			return fmt.Errorf("post_process.animated_graphics: end - start must not exceed 60000ms")
+		}
+		if !isAutoFitDimension(ag.Width) {
+			return fmt.Errorf("post_process.animated_graphics: width must be 0, -1, or between 32 and 3840")
+		}
+		if !isAutoFitDimension(ag.Height) {
+			return fmt.Errorf("post_process.animated_graphics: height must be 0, -1, or between 32 and 3840")
+		}
+		if ag.FrameRate < 1 || ag.FrameRate > 75 {
+			return fmt.Errorf("post_process.animated_graphics: frame_rate must be between 1 and 75")
+		}
+	}
+
+	if clip := pp.Clip; clip != nil {
+		if clip.Duration > 60000 {
+			return fmt.Errorf("post_process.clip: duration must not exceed 60000ms")
+		}
+	}
+
+	return nil
+}
+
+// isAutoFitDimension reports whether v is either an auto-fit sentinel (0 or
+// -1) or a concrete dimension within the supported [32, 3840] range.
+func isAutoFitDimension(v int) bool {
+	if v == 0 || v == -1 {
+		return true
+	}
+	return v >= 32 && v <= 3840
+}
+
 // GenerateVideo generates a video (non-streaming, async by default)
 func (c *Client) GenerateVideo(ctx context.Context, req interface{}) (*VideoResponse, error) {
 	if req == nil {
@@ -55,15 +97,43 @@ func (c *Client) GenerateVideo(ctx context.Context, req interface{}) (*VideoResp
 		return nil, err
 	}
 
+	if c.config.Router != nil {
+		if vr, ok := req.(*VideoRequest); ok {
+			if backend, err := c.config.Router.Resolve(vr.Model, string(vr.JobType)); err == nil {
+				return backend.GenerateVideo(ctx, req)
+			}
+		} else if m, ok := req.(map[string]interface{}); ok {
+			model, _ := m["model"].(string)
+			jobType, _ := m["job_type"].(string)
+			if backend, err := c.config.Router.Resolve(model, jobType); err == nil {
+				return backend.GenerateVideo(ctx, req)
+			}
+		}
+	}
+
 	var reqMap map[string]interface{}
 	err := c.doRequest(ctx, "POST", "/generation", req, &reqMap)
 	if err != nil {
 		return nil, err
 	}
-	return NewVideoResponse(reqMap)
+	resp, err := NewVideoResponse(reqMap)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.moderateVideoResponse(ctx, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
 // WaitForVideo polls async video job until completion
 func (c *Client) WaitForVideo(ctx context.Context, jobID string, opts *PollingOptions) (*VideoResponse, error) {
-	return c.PollVideoJobStatus(ctx, jobID, opts)
+	resp, err := c.PollVideoJobStatus(ctx, jobID, opts)
+	if err != nil {
+		return resp, err
+	}
+	if err := c.moderateVideoResponse(ctx, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
@@ -0,0 +1,220 @@
+package onethingai
+
+import (
+	"container/list"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// DefaultWebhookSignatureHeader is the header WebhookServer reads the
+// HMAC-SHA256 signature from when WebhookOptions.SignatureHeader is unset.
+const DefaultWebhookSignatureHeader = "X-Signature-256"
+
+// DefaultWebhookDedupCapacity is the number of job_id+event keys
+// WebhookServer.seen retains when WebhookOptions.DedupCapacity is unset.
+const DefaultWebhookDedupCapacity = 10000
+
+// WebhookJobKindHeader is the header the caller's provider setup is expected
+// to set to "image" or "video", telling WebhookServer which typed response
+// to decode the body as. The wire body (Response[ImageAndVideoDataResponse[T]])
+// is generic over T and carries no such discriminator itself.
+const WebhookJobKindHeader = "X-OneThingAI-Job-Kind"
+
+// WebhookOptions configures a WebhookServer.
+type WebhookOptions struct {
+	// Secret is the HMAC-SHA256 secret shared with the provider (normally
+	// the same value as the CallbackConfig.Secret used to register the
+	// job). Empty disables signature verification.
+	Secret string
+
+	// SignatureHeader is the header carrying the hex-encoded HMAC of the
+	// raw body. DefaultWebhookSignatureHeader if empty.
+	SignatureHeader string
+
+	// DedupCapacity bounds how many job_id+event keys the dedup set
+	// retains before evicting the least-recently-seen one.
+	// DefaultWebhookDedupCapacity if zero; a negative value disables the
+	// cap (unbounded growth, matching the server's pre-eviction behavior).
+	DedupCapacity int
+}
+
+// WebhookServer is an http.Handler that receives async job callbacks,
+// verifies their signature, and dispatches them to registered handlers. It
+// deduplicates on job_id + event type so a provider's at-least-once retry
+// doesn't invoke a handler twice for the same event. The dedup set is
+// capacity-bounded (WebhookOptions.DedupCapacity), evicting the
+// least-recently-seen key, so a long-running server's memory doesn't grow
+// without bound.
+type WebhookServer struct {
+	opts WebhookOptions
+
+	mu        sync.Mutex
+	onImage   func(*ImageResponse)
+	onVideo   func(*VideoResponse)
+	onError   func(error)
+	seenOrder *list.List
+	seen      map[string]*list.Element
+}
+
+// NewWebhookServer creates a WebhookServer from opts.
+func NewWebhookServer(opts WebhookOptions) *WebhookServer {
+	if opts.SignatureHeader == "" {
+		opts.SignatureHeader = DefaultWebhookSignatureHeader
+	}
+	if opts.DedupCapacity == 0 {
+		opts.DedupCapacity = DefaultWebhookDedupCapacity
+	}
+	return &WebhookServer{
+		opts:      opts,
+		seenOrder: list.New(),
+		seen:      make(map[string]*list.Element),
+	}
+}
+
+// HandleImage registers fn to be called for callbacks carrying an image job
+// result.
+func (s *WebhookServer) HandleImage(fn func(*ImageResponse)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onImage = fn
+}
+
+// HandleVideo registers fn to be called for callbacks carrying a video job
+// result.
+func (s *WebhookServer) HandleVideo(fn func(*VideoResponse)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onVideo = fn
+}
+
+// HandleError registers fn to be called when a callback fails signature
+// verification or can't be parsed.
+func (s *WebhookServer) HandleError(fn func(error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onError = fn
+}
+
+// ServeHTTP implements http.Handler, verifying the request's HMAC signature
+// (if a Secret is configured), deduping on job_id + event type, and
+// dispatching the parsed result to the registered Handle* callback.
+func (s *WebhookServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.reportError(fmt.Errorf("webhook: failed to read body: %w", err))
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	if s.opts.Secret != "" && !s.verifySignature(r, body) {
+		s.reportError(fmt.Errorf("webhook: signature verification failed"))
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var envelope struct {
+		Type string `json:"type"`
+		Data struct {
+			JobID string `json:"job_id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		s.reportError(fmt.Errorf("webhook: failed to parse body: %w", err))
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	if envelope.Data.JobID != "" && s.alreadySeen(envelope.Data.JobID, envelope.Type) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	jobKind := r.Header.Get(WebhookJobKindHeader)
+
+	s.mu.Lock()
+	onImage, onVideo := s.onImage, s.onVideo
+	s.mu.Unlock()
+
+	if jobKind == "video" {
+		resp, err := NewVideoResponse(body)
+		if err != nil {
+			s.reportError(fmt.Errorf("webhook: failed to parse video response: %w", err))
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if onVideo != nil {
+			onVideo(resp)
+		}
+	} else {
+		resp, err := NewImageResponse(body)
+		if err != nil {
+			s.reportError(fmt.Errorf("webhook: failed to parse image response: %w", err))
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if onImage != nil {
+			onImage(resp)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// verifySignature checks r's SignatureHeader against the HMAC-SHA256 of
+// body keyed by s.opts.Secret.
+func (s *WebhookServer) verifySignature(r *http.Request, body []byte) bool {
+	signature := r.Header.Get(s.opts.SignatureHeader)
+	if signature == "" {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.opts.Secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(signature), []byte(expected))
+}
+
+// alreadySeen reports whether job_id+eventType has been dispatched before,
+// recording it if not and evicting the least-recently-seen key if that
+// pushes the set past opts.DedupCapacity.
+func (s *WebhookServer) alreadySeen(jobID, eventType string) bool {
+	key := jobID + ":" + eventType
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.seen[key]; ok {
+		s.seenOrder.MoveToFront(el)
+		return true
+	}
+
+	el := s.seenOrder.PushFront(key)
+	s.seen[key] = el
+
+	if cap := s.opts.DedupCapacity; cap > 0 && s.seenOrder.Len() > cap {
+		oldest := s.seenOrder.Back()
+		if oldest != nil {
+			s.seenOrder.Remove(oldest)
+			delete(s.seen, oldest.Value.(string))
+		}
+	}
+	return false
+}
+
+// reportError dispatches err to the registered HandleError callback, if any.
+func (s *WebhookServer) reportError(err error) {
+	s.mu.Lock()
+	onError := s.onError
+	s.mu.Unlock()
+
+	if onError != nil {
+		onError(err)
+	}
+}